@@ -0,0 +1,171 @@
+// Package apperror berisi error domain yang dipakai service, middleware, dan
+// handler. Setiap error punya Kind (jenis kegagalan) dan Code yang stabil
+// untuk dibaca mesin; pesan untuk pengguna diambil dari katalog i18n
+// berdasarkan Code (dengan Args sebagai parameter), sedangkan Err adalah
+// penyebab internal yang hanya dicatat ke log.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go_clean/app/i18n"
+)
+
+type Kind int
+
+const (
+	KindInternal        Kind = iota // kegagalan tak terduga (database, hashing, ...)
+	KindInvalid                     // input tidak valid
+	KindUnauthorized                // belum/gagal autentikasi
+	KindForbidden                   // principal tidak berhak
+	KindNotFound                    // resource tidak ada
+	KindConflict                    // bentrok dengan data yang sudah ada
+	KindTooManyRequests             // dibatasi (lockout, throttle)
+	KindUpstream                    // layanan luar (IdP, SMTP) gagal
+	KindUnavailable                 // server sedang berhenti
+	KindTimeout                     // request melebihi batas waktu
+)
+
+// Status memetakan Kind ke status HTTP
+func (k Kind) Status() int {
+	switch k {
+	case KindInvalid:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	case KindUpstream:
+		return http.StatusBadGateway
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// FieldError adalah detail validasi untuk satu field request. Message diisi
+// saat respons ditulis, dari katalog "field.<Code>" dengan nama field sebagai
+// parameter pertama diikuti Args.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Args    []any  `json:"-"`
+}
+
+// Localize mengembalikan salinan f dengan Message dalam bahasa loc
+func (f FieldError) Localize(loc i18n.Locale) FieldError {
+	f.Message = i18n.T(loc, "field."+f.Code, append([]any{f.Field}, f.Args...)...)
+	return f
+}
+
+type Error struct {
+	Kind Kind
+	Code string
+	// Args adalah parameter pesan katalog untuk Code (mis. nama permission)
+	Args   []any
+	Fields []FieldError
+	// RetryAfter diisi untuk KindTooManyRequests
+	RetryAfter time.Duration
+	Err        error
+}
+
+// Message mengembalikan pesan untuk pengguna dalam bahasa loc
+func (e *Error) Message(loc i18n.Locale) string {
+	return i18n.T(loc, e.Code, e.Args...)
+}
+
+// Error memakai pesan bahasa default supaya log tetap mudah dibaca
+func (e *Error) Error() string {
+	msg := e.Code + ": " + e.Message(i18n.Default)
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// WithErr menempelkan penyebab internal (untuk log) ke error non-internal,
+// misalnya Unauthorized karena verifikasi ke IdP gagal
+func (e *Error) WithErr(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// New membuat error; code harus terdaftar di katalog i18n
+func New(kind Kind, code string, args ...any) *Error {
+	return &Error{Kind: kind, Code: code, Args: args}
+}
+
+func Invalid(code string, args ...any) *Error      { return New(KindInvalid, code, args...) }
+func Unauthorized(code string, args ...any) *Error { return New(KindUnauthorized, code, args...) }
+func Forbidden(code string, args ...any) *Error    { return New(KindForbidden, code, args...) }
+func NotFound(code string, args ...any) *Error     { return New(KindNotFound, code, args...) }
+func Conflict(code string, args ...any) *Error     { return New(KindConflict, code, args...) }
+
+// InvalidPayload dipakai saat body request tidak bisa di-parse
+func InvalidPayload() *Error {
+	return Invalid("invalid_payload")
+}
+
+// Validation adalah Invalid dengan detail per field
+func Validation(fields ...FieldError) *Error {
+	return &Error{Kind: KindInvalid, Code: "validation_failed", Fields: fields}
+}
+
+// Field membuat FieldError; pesannya dari katalog "field.<code>"
+func Field(field, code string, args ...any) FieldError {
+	return FieldError{Field: field, Code: code, Args: args}
+}
+
+// Required membuat FieldError untuk field wajib yang kosong
+func Required(field string) FieldError {
+	return Field(field, "required")
+}
+
+func TooManyRequests(code string, retryAfter time.Duration, args ...any) *Error {
+	return &Error{Kind: KindTooManyRequests, Code: code, Args: args, RetryAfter: retryAfter}
+}
+
+// Wrap membuat error internal dengan pesan yang aman ditampilkan; err hanya
+// masuk log
+func Wrap(err error, code string, args ...any) *Error {
+	return &Error{Kind: KindInternal, Code: code, Args: args, Err: err}
+}
+
+// Internal dipakai untuk kegagalan database dan sejenisnya yang tidak perlu
+// pesan khusus
+func Internal(err error) *Error {
+	return Wrap(err, "internal_error")
+}
+
+// Upstream dipakai saat layanan luar (IdP, SMTP) gagal
+func Upstream(err error, code string, args ...any) *Error {
+	return &Error{Kind: KindUpstream, Code: code, Args: args, Err: err}
+}
+
+// From mengembalikan *Error dari err; error lain dianggap internal
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return Internal(err)
+}
+
+// KindOf mengembalikan Kind dari err; error yang bukan *Error dianggap internal
+func KindOf(err error) Kind {
+	return From(err).Kind
+}
@@ -1,10 +1,140 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"strconv"
+
+	"go_clean/app/apperror"
+	"go_clean/app/models"
 	"go_clean/app/service"
+	"go_clean/helper"
+
+	"github.com/gofiber/fiber/v2"
 )
 
-func GetAlumniListHandler(c *fiber.Ctx) error {
-	return service.GetAlumniList(c)
+// AlumniHandler adalah lapisan HTTP untuk AlumniService: binding request,
+// parsing parameter, dan serialisasi response
+type AlumniHandler struct {
+	Svc *service.AlumniService
+}
+
+func (h *AlumniHandler) GetAll(c *fiber.Ctx) error {
+	alumni, err := h.Svc.GetAllAlumni(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_fetched"),
+		"data":    alumni,
+	})
+}
+
+func (h *AlumniHandler) List(c *fiber.Ctx) error {
+	resp, err := h.Svc.GetAlumniList(c.UserContext(), listParams(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(resp)
+}
+
+func (h *AlumniHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Invalid("invalid_alumni_id")
+	}
+	alumni, err := h.Svc.GetAlumniByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_fetched"),
+		"data":    alumni,
+	})
+}
+
+func (h *AlumniHandler) GetByAngkatan(c *fiber.Ctx) error {
+	angkatan, err := strconv.Atoi(c.Params("angkatan"))
+	if err != nil {
+		return apperror.Invalid("invalid_angkatan")
+	}
+	result, err := h.Svc.GetAlumniByAngkatan(c.UserContext(), angkatan)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_fetched"),
+		"data":    result,
+	})
+}
+
+func (h *AlumniHandler) GetWithPekerjaan(c *fiber.Ctx) error {
+	idStr := c.Params("nim") // sebenarnya ini ID
+	if idStr == "" {
+		return apperror.Invalid("invalid_alumni_id")
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return apperror.Invalid("invalid_alumni_id")
+	}
+	result, err := h.Svc.GetAlumniAndPekerjaan(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_with_pekerjaan_fetched"),
+		"data":    result,
+	})
+}
+
+func (h *AlumniHandler) Create(c *fiber.Ctx) error {
+	var req models.AlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	alumni, err := h.Svc.CreateAlumni(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_created"),
+		"data":    alumni,
+	})
+}
+
+func (h *AlumniHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Invalid("invalid_alumni_id")
+	}
+	var req models.AlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	alumni, err := h.Svc.UpdateAlumni(c.UserContext(), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_updated"),
+		"data":    alumni,
+	})
+}
+
+func (h *AlumniHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Invalid("invalid_alumni_id")
+	}
+	if err := h.Svc.DeleteAlumni(c.UserContext(), id); err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "alumni_deleted"),
+	})
 }
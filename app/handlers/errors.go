@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go_clean/app/apperror"
+	"go_clean/app/i18n"
+	"go_clean/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const mimeProblemJSON = "application/problem+json"
+
+// ErrorBody adalah isi "error" pada envelope error
+type ErrorBody struct {
+	Code       string                `json:"code"`
+	Message    string                `json:"message"`
+	Fields     []apperror.FieldError `json:"fields,omitempty"`
+	RetryAfter int                   `json:"retry_after,omitempty"`
+}
+
+// Problem adalah respons RFC 7807 (application/problem+json)
+type Problem struct {
+	Type       string                `json:"type"`
+	Title      string                `json:"title"`
+	Status     int                   `json:"status"`
+	Detail     string                `json:"detail"`
+	Instance   string                `json:"instance,omitempty"`
+	Code       string                `json:"code"`
+	Errors     []apperror.FieldError `json:"errors,omitempty"`
+	RetryAfter int                   `json:"retry_after,omitempty"`
+}
+
+// ErrorHandler adalah satu-satunya tempat error diubah menjadi respons HTTP.
+// Handler, service, dan middleware cukup mengembalikan error; dipasang lewat
+// fiber.Config.ErrorHandler.
+//
+// Envelope defaultnya {"success": false, "error": {"code", "message", ...}};
+// client yang mengirim "Accept: application/problem+json" mendapat RFC 7807.
+// Pesan diambil dari katalog i18n berdasarkan Code dalam bahasa request (lihat
+// helper.Locale). Penyebab internal (Err) hanya dicatat ke log, tidak pernah
+// dikirim ke client.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	e, status := classify(err)
+	if status >= 500 || e.Err != nil {
+		cause := e.Err
+		if cause == nil {
+			cause = err
+		}
+		log.Printf("%s %s -> %d %s: %v", c.Method(), c.OriginalURL(), status, e.Code, cause)
+	}
+
+	retryAfter := 0
+	if e.RetryAfter > 0 {
+		retryAfter = int(e.RetryAfter.Seconds()) + 1
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	}
+
+	loc := helper.Locale(c)
+	message := e.Message(loc)
+	var fields []apperror.FieldError
+	for _, f := range e.Fields {
+		fields = append(fields, f.Localize(loc))
+	}
+
+	c.Response().ResetBody()
+	c.Status(status)
+	if c.Accepts(fiber.MIMEApplicationJSON, mimeProblemJSON) == mimeProblemJSON {
+		return c.JSON(Problem{
+			Type:       "about:blank",
+			Title:      http.StatusText(status),
+			Status:     status,
+			Detail:     message,
+			Instance:   c.OriginalURL(),
+			Code:       e.Code,
+			Errors:     fields,
+			RetryAfter: retryAfter,
+		}, mimeProblemJSON)
+	}
+	return c.JSON(fiber.Map{
+		"success": false,
+		"error": ErrorBody{
+			Code:       e.Code,
+			Message:    message,
+			Fields:     fields,
+			RetryAfter: retryAfter,
+		},
+	})
+}
+
+// classify mengubah err menjadi *apperror.Error beserta status HTTP-nya.
+// *fiber.Error (route tidak ada, body terlalu besar, ...) memakai status
+// aslinya dengan code dari nama status; status yang tidak punya pesan di
+// katalog memakai code http_error.
+func classify(err error) (*apperror.Error, int) {
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		code := strings.ToLower(strings.ReplaceAll(http.StatusText(fe.Code), " ", "_"))
+		if !i18n.Has(code) {
+			code = "http_error"
+		}
+		kind := apperror.KindInvalid
+		if fe.Code >= 500 {
+			kind = apperror.KindInternal
+		}
+		return apperror.New(kind, code), fe.Code
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperror.New(apperror.KindTimeout, "request_timeout"), http.StatusGatewayTimeout
+	}
+	e := apperror.From(err)
+	return e, e.Kind.Status()
+}
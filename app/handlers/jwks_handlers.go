@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"go_clean/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JWKS mempublikasikan public key untuk verifikasi access token
+func JWKS(j *utils.JWT) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(j.JWKS())
+	}
+}
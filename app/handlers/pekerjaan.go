@@ -1,10 +1,181 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"strconv"
+
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/policy"
 	"go_clean/app/service"
+	"go_clean/helper"
+	"go_clean/middleware"
+
+	"github.com/gofiber/fiber/v2"
 )
 
-func GetPekerjaanListHandler(c *fiber.Ctx) error {
-	return service.GetPekerjaanList(c)
+// PekerjaanHandler adalah lapisan HTTP untuk PekerjaanService. Authz memuat
+// principal dari token; keputusan izin per pekerjaan ada di service.
+type PekerjaanHandler struct {
+	Svc   *service.PekerjaanService
+	Authz *policy.Authorizer
+}
+
+// principal membangun policy.Principal dari c.Locals yang diisi AuthRequired
+func (h *PekerjaanHandler) principal(c *fiber.Ctx) (*policy.Principal, error) {
+	userID, _ := c.Locals("user_id").(int)
+	perms, _ := c.Locals("permissions").([]string)
+	return h.Authz.Principal(c.UserContext(), userID, perms, middleware.ActorID(c))
+}
+
+// WithPrincipal membungkus endpoint /pekerjaan/.../:id yang dicek policy:
+// parsing ID dan memuat principal sebelum memanggil next
+func (h *PekerjaanHandler) WithPrincipal(next func(c *fiber.Ctx, id int, p policy.Principal) error) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			return apperror.Invalid("invalid_pekerjaan_id")
+		}
+		p, err := h.principal(c)
+		if err != nil {
+			return apperror.Wrap(err, "permission_check_failed")
+		}
+		return next(c, id, *p)
+	}
+}
+
+func (h *PekerjaanHandler) GetAll(c *fiber.Ctx) error {
+	pekerjaan, err := h.Svc.GetAllPekerjaan(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_fetched"),
+		"data":    pekerjaan,
+	})
+}
+
+func (h *PekerjaanHandler) List(c *fiber.Ctx) error {
+	resp, err := h.Svc.GetPekerjaanList(c.UserContext(), listParams(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(resp)
+}
+
+func (h *PekerjaanHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Invalid("invalid_pekerjaan_id")
+	}
+	pekerjaan, err := h.Svc.GetPekerjaanByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_fetched"),
+		"data":    pekerjaan,
+	})
+}
+
+func (h *PekerjaanHandler) GetByAlumniID(c *fiber.Ctx) error {
+	alumniID, err := strconv.Atoi(c.Params("alumni_id"))
+	if err != nil {
+		return apperror.Invalid("invalid_alumni_id")
+	}
+	pekerjaan, err := h.Svc.GetPekerjaanByAlumniID(c.UserContext(), alumniID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_by_alumni_fetched"),
+		"data":    pekerjaan,
+	})
+}
+
+func (h *PekerjaanHandler) Create(c *fiber.Ctx) error {
+	var req models.PekerjaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	pekerjaan, err := h.Svc.CreatePekerjaan(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_created"),
+		"data":    pekerjaan,
+	})
+}
+
+func (h *PekerjaanHandler) Update(c *fiber.Ctx, id int, p policy.Principal) error {
+	var req models.PekerjaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	updated, err := h.Svc.UpdatePekerjaan(c.UserContext(), p, id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_updated"),
+		"data":    updated,
+	})
+}
+
+func (h *PekerjaanHandler) Delete(c *fiber.Ctx, id int, p policy.Principal) error {
+	if err := h.Svc.DeletePekerjaan(c.UserContext(), p, id); err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_trashed"),
+	})
+}
+
+func (h *PekerjaanHandler) Trash(c *fiber.Ctx) error {
+	p, err := h.principal(c)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	pekerjaan, err := h.Svc.TrashPekerjaan(c.UserContext(), *p)
+	if err != nil {
+		return err
+	}
+	if len(pekerjaan) == 0 {
+		return c.JSON(fiber.Map{
+			"success": true,
+			"message": helper.Message(c, "trash_empty"),
+			"data":    []interface{}{},
+		})
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "trash_fetched"),
+		"data":    pekerjaan,
+	})
+}
+
+func (h *PekerjaanHandler) Restore(c *fiber.Ctx, id int, p policy.Principal) error {
+	if err := h.Svc.RestorePekerjaan(c.UserContext(), p, id); err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_restored"),
+	})
+}
+
+func (h *PekerjaanHandler) HardDelete(c *fiber.Ctx, id int, p policy.Principal) error {
+	if err := h.Svc.HardDeletePekerjaan(c.UserContext(), p, id); err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": helper.Message(c, "pekerjaan_hard_deleted"),
+	})
 }
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"strconv"
+
+	"go_clean/app/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// listParams membaca page, limit, sortBy, order, dan search dari query.
+// Default dan batasnya diterapkan service.
+func listParams(c *fiber.Ctx) service.ListParams {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	return service.ListParams{
+		Page:   page,
+		Limit:  limit,
+		SortBy: c.Query("sortBy", "id"),
+		Order:  c.Query("order", "asc"),
+		Search: c.Query("search", ""),
+	}
+}
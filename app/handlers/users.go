@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"strconv"
+
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/service"
+	"go_clean/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UserHandler adalah lapisan HTTP untuk register dan administrasi user
+// (/api/users, butuh permission users:manage)
+type UserHandler struct {
+	Svc *service.UserService
+}
+
+func userIDParam(c *fiber.Ctx) (int, error) {
+	return strconv.Atoi(c.Params("id"))
+}
+
+// actorID adalah admin yang sedang login, dipakai service untuk menolak aksi
+// terhadap akun sendiri
+func actorID(c *fiber.Ctx) int {
+	id, _ := c.Locals("user_id").(int)
+	return id
+}
+
+func errInvalidUserID() error {
+	return apperror.Invalid("invalid_user_id")
+}
+
+// PUBLIC: register user (role = "user" fixed)
+func (h *UserHandler) Register(c *fiber.Ctx) error {
+	var req models.RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	u, tokens, err := h.Svc.Register(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":       helper.Message(c, "user_registered"),
+		"user":          u,
+		"token":         tokens.Token,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+// ADMIN ONLY: create user/admin
+func (h *UserHandler) AdminCreate(c *fiber.Ctx) error {
+	var req models.AdminCreateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	u, err := h.Svc.AdminCreateUser(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": helper.Message(c, "user_created"),
+		"user":    u,
+	})
+}
+
+func (h *UserHandler) List(c *fiber.Ctx) error {
+	resp, err := h.Svc.ListUsers(c.UserContext(), listParams(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(resp)
+}
+
+func (h *UserHandler) Get(c *fiber.Ctx) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	u, err := h.Svc.GetUser(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": u})
+}
+
+func (h *UserHandler) UpdateRole(c *fiber.Ctx) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	var req models.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	u, err := h.Svc.UpdateUserRole(c.UserContext(), actorID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": u})
+}
+
+func (h *UserHandler) Disable(c *fiber.Ctx) error {
+	return h.setDisabled(c, true)
+}
+
+func (h *UserHandler) Enable(c *fiber.Ctx) error {
+	return h.setDisabled(c, false)
+}
+
+func (h *UserHandler) setDisabled(c *fiber.Ctx, disabled bool) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	u, err := h.Svc.SetDisabled(c.UserContext(), actorID(c), id, disabled)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": u})
+}
+
+func (h *UserHandler) Delete(c *fiber.Ctx) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	if err := h.Svc.DeleteUser(c.UserContext(), actorID(c), id); err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "user_deleted")})
+}
+
+func (h *UserHandler) ForcePasswordReset(c *fiber.Ctx) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	if err := h.Svc.ForcePasswordReset(c.UserContext(), id); err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "password_reset_forced")})
+}
+
+func (h *UserHandler) LinkAlumni(c *fiber.Ctx) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	var req models.LinkAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	u, err := h.Svc.LinkAlumni(c.UserContext(), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": u})
+}
+
+func (h *UserHandler) Impersonate(c *fiber.Ctx) error {
+	id, err := userIDParam(c)
+	if err != nil {
+		return errInvalidUserID()
+	}
+	resp, err := h.Svc.Impersonate(c.UserContext(), actorID(c), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(resp)
+}
@@ -0,0 +1,222 @@
+package i18n
+
+// en adalah katalog bahasa Inggris; urutan dan pengelompokannya mengikuti id
+var en = map[string]string{
+	// umum & HTTP
+	"invalid_payload":          "invalid request payload",
+	"validation_failed":        "some fields are invalid",
+	"internal_error":           "an internal server error occurred",
+	"request_timeout":          "request exceeded the time limit",
+	"server_shutting_down":     "server is shutting down",
+	"bad_request":              "bad request",
+	"not_found":                "endpoint not found",
+	"method_not_allowed":       "method not allowed for this endpoint",
+	"request_entity_too_large": "request body is too large",
+	"unsupported_media_type":   "unsupported content type",
+	"too_many_requests":        "too many requests, try again later",
+	"service_unavailable":      "service is temporarily unavailable",
+	"http_error":               "request could not be processed",
+
+	// detail validasi per field; parameter pertama selalu nama field
+	"field.required":              "%s is required",
+	"field.invalid_format":        "%s has an invalid format",
+	"field.not_found":             "%s was not found",
+	"field.too_large":             "%s exceeds the maximum allowed",
+	"field.unsupported_locale":    "%[1]s is not supported, choose one of: %[2]s",
+	"field.password_too_short":    "%[1]s must be at least %[2]d characters",
+	"field.password_needs_upper":  "%s must contain an uppercase letter",
+	"field.password_needs_lower":  "%s must contain a lowercase letter",
+	"field.password_needs_digit":  "%s must contain a digit",
+	"field.password_needs_symbol": "%s must contain a symbol",
+
+	// autentikasi & sesi
+	"token_required":          "token required",
+	"token_malformed":         "malformed token",
+	"token_invalid":           "token is invalid or expired",
+	"session_revoked":         "session has been revoked",
+	"session_check_failed":    "failed to check session",
+	"session_required":        "this request is not using a login session",
+	"session_revoke_failed":   "failed to revoke sessions",
+	"invalid_credentials":     "invalid username or password",
+	"account_disabled":        "account is disabled",
+	"password_reset_required": "password must be reset, check your email for the reset link",
+	"login_locked":            "too many login attempts, try again later",
+	"refresh_token_invalid":   "invalid refresh token",
+	"refresh_token_expired":   "refresh token has expired",
+	"refresh_token_reused":    "refresh token was already used, session revoked",
+	"token_issue_failed":      "failed to issue token",
+	"email_not_verified":      "verify your email before changing data",
+	"permission_denied":       "missing permission: %s",
+	"permission_check_failed": "failed to check permissions",
+	"logged_out":              "logged out",
+
+	// API key
+	"api_key_malformed":       "invalid API key",
+	"api_key_invalid":         "API key is invalid or expired",
+	"api_key_not_allowed":     "this endpoint cannot be accessed with an API key",
+	"api_key_check_failed":    "failed to check API key",
+	"api_key_owner_disabled":  "the key owner's account is disabled",
+	"invalid_api_key_id":      "invalid API key ID",
+	"api_key_not_found":       "API key not found or already revoked",
+	"scope_privileged":        "scope cannot be granted to an API key because it requires 2FA: %s",
+	"scope_not_allowed":       "scope is outside the user's role permissions: %s",
+	"api_key_fetch_failed":    "failed to fetch API keys",
+	"api_key_generate_failed": "failed to generate key",
+	"api_key_save_failed":     "failed to save API key",
+	"api_key_revoked":         "API key revoked",
+
+	// impersonasi
+	"impersonation_not_allowed":     "this endpoint cannot be accessed while impersonating",
+	"cannot_impersonate_self":       "you cannot impersonate your own account",
+	"impersonate_admin_not_allowed": "admins cannot impersonate other admins",
+
+	// 2FA
+	"challenge_token_invalid":   "challenge token is invalid or expired",
+	"mfa_already_enabled":       "2FA is already enabled",
+	"mfa_not_setup":             "2FA has not been set up",
+	"mfa_code_invalid":          "invalid 2FA code",
+	"mfa_required":              "2FA is required for accounts with account-management permissions",
+	"mfa_not_enabled":           "2FA is not enabled",
+	"mfa_secret_failed":         "failed to create 2FA secret",
+	"mfa_enable_failed":         "failed to enable 2FA",
+	"mfa_recovery_codes_failed": "failed to create recovery codes",
+	"mfa_disable_failed":        "failed to disable 2FA",
+	"mfa_enabled":               "2FA enabled",
+	"mfa_disabled":              "2FA disabled",
+
+	// SSO (OIDC)
+	"oidc_disabled":               "SSO login is disabled",
+	"oidc_state_invalid":          "state is invalid or expired, please log in again",
+	"oidc_verification_failed":    "SSO login could not be verified",
+	"oidc_login_cancelled":        "SSO login was cancelled: %s",
+	"oidc_no_account":             "this SSO identity is not linked to any account; log in with your password and link SSO, or contact an admin",
+	"oidc_state_failed":           "failed to generate state",
+	"oidc_nonce_failed":           "failed to generate nonce",
+	"oidc_pkce_failed":            "failed to generate code verifier",
+	"oidc_provider_unreachable":   "SSO provider is unreachable",
+	"oidc_account_mapping_failed": "failed to map SSO account",
+	"oidc_identity_taken":         "this SSO identity is already linked to another account",
+	"oidc_link_code_invalid":      "SSO link code is invalid or expired",
+	"oidc_link_failed":            "failed to link SSO account",
+	"oidc_link_pending":           "SSO identity verified, confirm the link from your account",
+	"oidc_linked":                 "SSO account linked",
+
+	// password & verifikasi email
+	"current_password_wrong":    "current password is incorrect",
+	"password_unchanged":        "new password must be different",
+	"password_hash_failed":      "failed to hash password",
+	"password_save_failed":      "failed to save password",
+	"reset_token_invalid":       "reset token is invalid or expired",
+	"reset_email_failed":        "failed to send password reset email",
+	"password_changed":          "password changed, other sessions have been revoked",
+	"password_reset_requested":  "if the email is registered, a password reset link has been sent",
+	"password_reset_done":       "password has been reset, please log in again",
+	"email_already_verified":    "email is already verified",
+	"verification_link_invalid": "verification link is invalid or expired",
+	"verification_link_used":    "verification link was already used or the email has changed",
+	"verification_throttled":    "a verification link was just sent, try again later",
+	"verification_email_failed": "failed to send verification email",
+	"verification_sent":         "verification link sent to %s",
+	"email_verified":            "email verified, refresh your token for full access",
+
+	// user & profil
+	"invalid_user_id":        "invalid user ID",
+	"user_not_found":         "user not found",
+	"user_exists":            "username or email is already taken",
+	"user_fetch_failed":      "failed to fetch user data",
+	"user_count_failed":      "failed to count users",
+	"user_create_failed":     "failed to create user",
+	"user_delete_failed":     "failed to delete user",
+	"user_in_use":            "user is still referenced by other data and cannot be deleted",
+	"cannot_change_own_role": "you cannot change your own role",
+	"cannot_disable_self":    "you cannot disable your own account",
+	"cannot_delete_self":     "you cannot delete your own account",
+	"alumni_already_linked":  "alumni is already linked to another account",
+	"alumni_link_failed":     "failed to link alumni",
+	"profile_fetch_failed":   "failed to fetch profile",
+	"profile_save_failed":    "failed to save profile",
+	"user_registered":        "registration successful, check your email to verify your account",
+	"user_created":           "user created",
+	"user_deleted":           "user deleted",
+	"password_reset_forced":  "user must reset their password, a link has been sent",
+	"account_unlocked":       "account unlocked",
+
+	// role & permission
+	"unknown_role":            "unknown role",
+	"unknown_permission":      "unknown permission: %s",
+	"role_fetch_failed":       "failed to fetch roles",
+	"permission_fetch_failed": "failed to fetch permissions",
+	"role_update_failed":      "failed to change role",
+	"role_save_failed":        "failed to save role",
+	"role_saved":              "role saved",
+	"role_last_holder":        "this change would leave no active user holding %s",
+
+	// klaim akun alumni
+	"account_already_linked":  "account is already linked to alumni data",
+	"alumni_not_linked":       "account is not linked to alumni data",
+	"nim_not_found":           "student ID (NIM) not found",
+	"invalid_claim_id":        "invalid claim ID",
+	"claim_not_found":         "claim not found",
+	"claim_not_pending":       "claim is not awaiting verification",
+	"claim_code_expired":      "verification code has expired, create a new claim",
+	"claim_code_invalid":      "invalid verification code",
+	"claim_not_disputable":    "claim cannot be escalated to an admin",
+	"claim_already_decided":   "claim has already been decided",
+	"claim_already_pending":   "a verification code for this claim is still valid, check the alumni email",
+	"claim_rate_limited":      "too many new claims, try again later",
+	"claim_too_many_attempts": "too many attempts, request an admin review",
+	"claim_save_failed":       "failed to save claim",
+	"claim_code_failed":       "failed to create verification code",
+	"claim_code_email_failed": "failed to send verification code",
+	"claim_fetch_failed":      "failed to fetch claims",
+	"account_link_failed":     "failed to link account",
+	"claim_review_required":   "claim requires admin review",
+	"claim_code_sent":         "verification code sent to %s",
+	"claim_escalated":         "alumni is already claimed by another account, claim forwarded to an admin",
+	"claim_disputed":          "claim forwarded to an admin",
+	"account_linked":          "account linked to alumni data",
+	"claim_approved":          "claim approved",
+	"claim_rejected":          "claim rejected",
+
+	// alumni
+	"invalid_alumni_id":             "invalid alumni ID",
+	"invalid_angkatan":              "invalid graduation year",
+	"alumni_not_found":              "Alumni not found",
+	"alumni_duplicate":              "alumni NIM or email is already taken",
+	"alumni_fetch_failed":           "Failed to fetch alumni data",
+	"alumni_count_failed":           "Failed to count alumni",
+	"alumni_create_failed":          "Failed to create alumni",
+	"alumni_update_failed":          "Failed to update alumni",
+	"alumni_delete_failed":          "Failed to delete alumni",
+	"alumni_fetched":                "Alumni data retrieved successfully",
+	"alumni_with_pekerjaan_fetched": "Alumni and employment data retrieved successfully",
+	"alumni_created":                "Alumni created successfully",
+	"alumni_updated":                "Alumni updated successfully",
+	"alumni_deleted":                "Alumni deleted successfully",
+
+	// pekerjaan
+	"invalid_pekerjaan_id":            "invalid employment ID",
+	"pekerjaan_not_found":             "Employment record not found",
+	"pekerjaan_fetch_failed":          "Failed to fetch employment data",
+	"pekerjaan_count_failed":          "Failed to count employment records",
+	"pekerjaan_create_failed":         "Failed to create employment record",
+	"pekerjaan_update_failed":         "Failed to update employment record",
+	"pekerjaan_delete_failed":         "Failed to delete employment record",
+	"pekerjaan_restore_failed":        "Failed to restore employment record",
+	"pekerjaan_hard_delete_failed":    "Failed to permanently delete employment record",
+	"pekerjaan_update_forbidden":      "You are not allowed to update this employment record",
+	"pekerjaan_delete_forbidden":      "You are not allowed to delete this employment record",
+	"pekerjaan_restore_forbidden":     "You are not allowed to restore this employment record",
+	"pekerjaan_hard_delete_forbidden": "You are not allowed to permanently delete this employment record",
+	"unknown_action":                  "unknown action",
+	"pekerjaan_fetched":               "Employment data retrieved successfully",
+	"pekerjaan_by_alumni_fetched":     "Employment data for the alumni retrieved successfully",
+	"pekerjaan_created":               "Employment record created successfully",
+	"pekerjaan_updated":               "Employment record updated successfully",
+	"pekerjaan_deleted":               "Employment record deleted successfully",
+	"pekerjaan_trashed":               "Employment record moved to trash",
+	"pekerjaan_restored":              "Employment record restored successfully",
+	"pekerjaan_hard_deleted":          "Employment record permanently deleted",
+	"trash_empty":                     "There are no employment records in the trash",
+	"trash_fetched":                   "Trashed employment data retrieved successfully",
+}
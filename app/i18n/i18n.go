@@ -0,0 +1,107 @@
+// Package i18n berisi katalog pesan API per bahasa. Pesan dicari berdasarkan
+// code yang stabil (code error apperror, "field.<code>" untuk detail validasi,
+// atau code pesan sukses); bahasa yang tidak punya terjemahan jatuh ke
+// Default, dan code yang tidak terdaftar dikembalikan apa adanya.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale adalah bahasa utama (ISO 639-1) yang didukung API
+type Locale string
+
+const (
+	ID Locale = "id"
+	EN Locale = "en"
+
+	// Default dipakai jika client tidak meminta bahasa yang didukung
+	Default = ID
+)
+
+var catalogs = map[Locale]map[string]string{
+	ID: id,
+	EN: en,
+}
+
+// Supported mengembalikan semua locale yang punya katalog, terurut
+func Supported() []Locale {
+	locales := make([]Locale, 0, len(catalogs))
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+	return locales
+}
+
+// Parse menerima language tag seperti "en", "en-US", atau "id_ID"; hanya
+// bahasa utamanya yang dipakai. ok false jika bahasa tidak didukung.
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "in" { // kode lama untuk bahasa Indonesia
+		tag = string(ID)
+	}
+	l := Locale(tag)
+	_, ok := catalogs[l]
+	return l, ok
+}
+
+// Negotiate memilih locale terbaik dari header Accept-Language (RFC 9110)
+// berdasarkan q-value; urutan di header menentukan jika q sama. ok false jika
+// tidak ada bahasa yang didukung, caller memakai Default.
+func Negotiate(header string) (Locale, bool) {
+	var (
+		best  Locale
+		bestQ float64
+	)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= bestQ {
+			continue
+		}
+		l, ok := Parse(tag)
+		if strings.TrimSpace(tag) == "*" {
+			l, ok = Default, true
+		}
+		if ok {
+			best, bestQ = l, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// T mengembalikan pesan code dalam bahasa loc, diformat dengan args
+// (fmt.Sprintf) jika ada
+func T(loc Locale, code string, args ...any) string {
+	msg, ok := catalogs[loc][code]
+	if !ok {
+		msg, ok = catalogs[Default][code]
+	}
+	if !ok {
+		return code
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Has true jika code terdaftar di katalog Default
+func Has(code string) bool {
+	_, ok := catalogs[Default][code]
+	return ok
+}
@@ -0,0 +1,185 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+		ok     bool
+	}{
+		{"", "", false},
+		{"en", EN, true},
+		{"en-US,en;q=0.9", EN, true},
+		{"id-ID", ID, true},
+		{"in", ID, true},
+		{"fr-FR, en;q=0.3, id;q=0.7", ID, true},
+		{"id;q=0.2, EN-gb;q=0.8", EN, true},
+		{"en;q=0.5, id;q=0.5", EN, true},
+		{"fr, de;q=0.8", "", false},
+		{"en;q=0, id;q=0", "", false},
+		{"en;q=abc, id;q=0.1", ID, true},
+		{"fr, *;q=0.5", Default, true},
+	}
+	for _, tt := range tests {
+		got, ok := Negotiate(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Negotiate(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(EN, "alumni_not_found"); got != "Alumni not found" {
+		t.Errorf("T(en) = %q", got)
+	}
+	if got := T(Locale("fr"), "alumni_not_found"); got != "Alumni tidak ditemukan" {
+		t.Errorf("locale tanpa katalog harus jatuh ke Default, dapat %q", got)
+	}
+	if got := T(EN, "permission_denied", "alumni:write"); got != "missing permission: alumni:write" {
+		t.Errorf("T dengan args = %q", got)
+	}
+	if got := T(ID, "field.password_too_short", "password", 8); got != "password minimal 8 karakter" {
+		t.Errorf("T field = %q", got)
+	}
+	if got := T(EN, "no_such_code"); got != "no_such_code" {
+		t.Errorf("code tak terdaftar = %q, want code apa adanya", got)
+	}
+}
+
+var verb = regexp.MustCompile(`%(?:\[\d+\])?[sdv]`)
+
+// probeArgs membuat argumen dummy sesuai verb di pesan Default
+func probeArgs(msg string) []any {
+	var args []any
+	for _, v := range verb.FindAllString(msg, -1) {
+		if strings.HasSuffix(v, "d") {
+			args = append(args, 1)
+		} else {
+			args = append(args, "x")
+		}
+	}
+	return args
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, loc := range Supported() {
+		for code := range catalogs[loc] {
+			if _, ok := catalogs[Default][code]; !ok {
+				t.Errorf("%s: code %q tidak ada di katalog %s", loc, code, Default)
+			}
+		}
+		for code, msg := range catalogs[Default] {
+			if _, ok := catalogs[loc][code]; !ok {
+				t.Errorf("%s: terjemahan %q belum ada", loc, code)
+				continue
+			}
+			// parameter terjemahan harus sama jumlah dan jenisnya
+			if got := T(loc, code, probeArgs(msg)...); strings.Contains(got, "%!") {
+				t.Errorf("%s: parameter %q tidak cocok: %s", loc, code, got)
+			}
+		}
+	}
+}
+
+// codeArg memetakan fungsi yang menerima code pesan ke posisi argumennya dan
+// prefix kunci katalognya
+var codeArg = map[string]struct {
+	pos    int
+	prefix string
+}{
+	"apperror.New":             {1, ""},
+	"apperror.Invalid":         {0, ""},
+	"apperror.Unauthorized":    {0, ""},
+	"apperror.Forbidden":       {0, ""},
+	"apperror.NotFound":        {0, ""},
+	"apperror.Conflict":        {0, ""},
+	"apperror.TooManyRequests": {0, ""},
+	"apperror.Wrap":            {1, ""},
+	"apperror.Upstream":        {1, ""},
+	"apperror.Field":           {1, "field."},
+	"helper.Message":           {1, ""},
+}
+
+// TestSourceCodesInCatalog memastikan setiap code literal yang dipakai di
+// source (error, detail field, pesan sukses) punya pesan di katalog
+func TestSourceCodesInCatalog(t *testing.T) {
+	fset := token.NewFileSet()
+	found := 0
+	check := func(pos token.Pos, lit ast.Expr, prefix string) {
+		bl, ok := lit.(*ast.BasicLit)
+		if !ok || bl.Kind != token.STRING {
+			return
+		}
+		code, _ := strconv.Unquote(bl.Value)
+		found++
+		if !Has(prefix + code) {
+			t.Errorf("%s: code %q tidak ada di katalog", fset.Position(pos), prefix+code)
+		}
+	}
+
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") && path != "../.." || d.Name() == "vendor" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				pkg, ok := sel.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				if a, ok := codeArg[pkg.Name+"."+sel.Sel.Name]; ok && len(n.Args) > a.pos {
+					check(n.Pos(), n.Args[a.pos], a.prefix)
+				}
+			case *ast.CompositeLit:
+				// utils.PasswordProblem{Code: "..."}
+				if id, ok := n.Type.(*ast.Ident); !ok || id.Name != "PasswordProblem" {
+					return true
+				}
+				for _, el := range n.Elts {
+					if kv, ok := el.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Code" {
+							check(kv.Pos(), kv.Value, "field.")
+						}
+					}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found < 100 {
+		t.Fatalf("hanya %d code ditemukan, pemindaian source sepertinya salah", found)
+	}
+}
@@ -0,0 +1,223 @@
+package i18n
+
+// id adalah katalog bahasa Indonesia sekaligus Default; setiap code baru
+// wajib ada di sini dan di en
+var id = map[string]string{
+	// umum & HTTP
+	"invalid_payload":          "payload tidak valid",
+	"validation_failed":        "data yang dikirim tidak valid",
+	"internal_error":           "terjadi kesalahan pada server",
+	"request_timeout":          "request melebihi batas waktu",
+	"server_shutting_down":     "server sedang berhenti",
+	"bad_request":              "request tidak valid",
+	"not_found":                "endpoint tidak ditemukan",
+	"method_not_allowed":       "method tidak diizinkan untuk endpoint ini",
+	"request_entity_too_large": "body request terlalu besar",
+	"unsupported_media_type":   "tipe konten tidak didukung",
+	"too_many_requests":        "terlalu banyak request, coba lagi nanti",
+	"service_unavailable":      "layanan sedang tidak tersedia",
+	"http_error":               "request tidak dapat diproses",
+
+	// detail validasi per field; parameter pertama selalu nama field
+	"field.required":              "%s wajib diisi",
+	"field.invalid_format":        "format %s tidak valid",
+	"field.not_found":             "%s tidak ditemukan",
+	"field.too_large":             "%s melebihi batas maksimal",
+	"field.unsupported_locale":    "%[1]s tidak didukung, pilih salah satu: %[2]s",
+	"field.password_too_short":    "%[1]s minimal %[2]d karakter",
+	"field.password_needs_upper":  "%s harus mengandung huruf besar",
+	"field.password_needs_lower":  "%s harus mengandung huruf kecil",
+	"field.password_needs_digit":  "%s harus mengandung angka",
+	"field.password_needs_symbol": "%s harus mengandung simbol",
+
+	// autentikasi & sesi
+	"token_required":          "butuh token",
+	"token_malformed":         "format token salah",
+	"token_invalid":           "token invalid/expired",
+	"session_revoked":         "sesi sudah dicabut",
+	"session_check_failed":    "gagal memeriksa sesi",
+	"session_required":        "request ini tidak memakai sesi login",
+	"session_revoke_failed":   "gagal mencabut sesi",
+	"invalid_credentials":     "username/password salah",
+	"account_disabled":        "akun dinonaktifkan",
+	"password_reset_required": "password harus direset, cek email untuk link reset",
+	"login_locked":            "terlalu banyak percobaan login, coba lagi nanti",
+	"refresh_token_invalid":   "refresh token tidak valid",
+	"refresh_token_expired":   "refresh token kedaluwarsa",
+	"refresh_token_reused":    "refresh token sudah dipakai, sesi dicabut",
+	"token_issue_failed":      "gagal generate token",
+	"email_not_verified":      "verifikasi email dulu sebelum mengubah data",
+	"permission_denied":       "tidak punya izin: %s",
+	"permission_check_failed": "Gagal memeriksa izin",
+	"logged_out":              "logout sukses",
+
+	// API key
+	"api_key_malformed":       "API key invalid",
+	"api_key_invalid":         "API key invalid/expired",
+	"api_key_not_allowed":     "endpoint ini tidak bisa diakses dengan API key",
+	"api_key_check_failed":    "gagal memeriksa API key",
+	"api_key_owner_disabled":  "akun pemilik key nonaktif",
+	"invalid_api_key_id":      "ID API key tidak valid",
+	"api_key_not_found":       "API key tidak ditemukan atau sudah dicabut",
+	"scope_privileged":        "scope tidak boleh diberikan ke API key karena mewajibkan 2FA: %s",
+	"scope_not_allowed":       "scope di luar permission role user: %s",
+	"api_key_fetch_failed":    "gagal mengambil data API key",
+	"api_key_generate_failed": "gagal generate key",
+	"api_key_save_failed":     "gagal menyimpan API key",
+	"api_key_revoked":         "API key dicabut",
+
+	// impersonasi
+	"impersonation_not_allowed":     "endpoint ini tidak bisa diakses saat impersonasi",
+	"cannot_impersonate_self":       "tidak bisa impersonasi akun sendiri",
+	"impersonate_admin_not_allowed": "tidak bisa impersonasi sesama admin",
+
+	// 2FA
+	"challenge_token_invalid":   "challenge token invalid/expired",
+	"mfa_already_enabled":       "2FA sudah aktif",
+	"mfa_not_setup":             "2FA belum di-setup",
+	"mfa_code_invalid":          "kode 2FA salah",
+	"mfa_required":              "2FA wajib untuk akun dengan hak kelola akun",
+	"mfa_not_enabled":           "2FA belum aktif",
+	"mfa_secret_failed":         "gagal membuat secret 2FA",
+	"mfa_enable_failed":         "gagal mengaktifkan 2FA",
+	"mfa_recovery_codes_failed": "gagal membuat recovery code",
+	"mfa_disable_failed":        "gagal menonaktifkan 2FA",
+	"mfa_enabled":               "2FA aktif",
+	"mfa_disabled":              "2FA dinonaktifkan",
+
+	// SSO (OIDC)
+	"oidc_disabled":               "login SSO tidak aktif",
+	"oidc_state_invalid":          "state tidak valid atau kedaluwarsa, ulangi login",
+	"oidc_verification_failed":    "login SSO gagal diverifikasi",
+	"oidc_login_cancelled":        "login SSO dibatalkan: %s",
+	"oidc_no_account":             "akun SSO belum terhubung ke akun mana pun; login dengan password lalu tautkan SSO, atau hubungi admin",
+	"oidc_state_failed":           "gagal generate state",
+	"oidc_nonce_failed":           "gagal generate nonce",
+	"oidc_pkce_failed":            "gagal generate code verifier",
+	"oidc_provider_unreachable":   "provider SSO tidak bisa dihubungi",
+	"oidc_account_mapping_failed": "gagal memetakan akun SSO",
+	"oidc_identity_taken":         "identitas SSO ini sudah terhubung ke akun lain",
+	"oidc_link_code_invalid":      "kode penautan SSO tidak valid atau kedaluwarsa",
+	"oidc_link_failed":            "gagal menautkan akun SSO",
+	"oidc_link_pending":           "identitas SSO terverifikasi, konfirmasi penautan dari akun kamu",
+	"oidc_linked":                 "akun SSO berhasil ditautkan",
+
+	// password & verifikasi email
+	"current_password_wrong":    "password lama salah",
+	"password_unchanged":        "password baru harus berbeda",
+	"password_hash_failed":      "gagal hash password",
+	"password_save_failed":      "gagal menyimpan password",
+	"reset_token_invalid":       "token reset tidak valid atau kedaluwarsa",
+	"reset_email_failed":        "gagal mengirim email reset password",
+	"password_changed":          "password berhasil diganti, sesi lain sudah dicabut",
+	"password_reset_requested":  "jika email terdaftar, link reset password sudah dikirim",
+	"password_reset_done":       "password berhasil direset, silakan login ulang",
+	"email_already_verified":    "email sudah terverifikasi",
+	"verification_link_invalid": "link verifikasi tidak valid atau kedaluwarsa",
+	"verification_link_used":    "link verifikasi sudah dipakai atau email sudah diganti",
+	"verification_throttled":    "link verifikasi baru saja dikirim, coba lagi nanti",
+	"verification_email_failed": "gagal mengirim email verifikasi",
+	"verification_sent":         "link verifikasi dikirim ke %s",
+	"email_verified":            "email terverifikasi, silakan refresh token untuk akses penuh",
+
+	// user & profil
+	"invalid_user_id":        "ID user tidak valid",
+	"user_not_found":         "user tidak ditemukan",
+	"user_exists":            "username/email sudah dipakai",
+	"user_fetch_failed":      "gagal mengambil data user",
+	"user_count_failed":      "gagal menghitung data user",
+	"user_create_failed":     "gagal membuat user",
+	"user_delete_failed":     "gagal menghapus user",
+	"user_in_use":            "user masih dipakai data lain sehingga tidak bisa dihapus",
+	"cannot_change_own_role": "tidak bisa mengubah role sendiri",
+	"cannot_disable_self":    "tidak bisa menonaktifkan akun sendiri",
+	"cannot_delete_self":     "tidak bisa menghapus akun sendiri",
+	"alumni_already_linked":  "alumni sudah tertaut ke akun lain",
+	"alumni_link_failed":     "gagal menautkan alumni",
+	"profile_fetch_failed":   "gagal mengambil profil",
+	"profile_save_failed":    "gagal menyimpan profil",
+	"user_registered":        "register sukses, cek email untuk verifikasi akun",
+	"user_created":           "user dibuat",
+	"user_deleted":           "user dihapus",
+	"password_reset_forced":  "user wajib reset password, link sudah dikirim",
+	"account_unlocked":       "akun berhasil dibuka",
+
+	// role & permission
+	"unknown_role":            "role tidak dikenal",
+	"unknown_permission":      "permission tidak dikenal: %s",
+	"role_fetch_failed":       "gagal mengambil data role",
+	"permission_fetch_failed": "gagal mengambil data permission",
+	"role_update_failed":      "gagal mengubah role",
+	"role_save_failed":        "gagal menyimpan role",
+	"role_saved":              "role disimpan",
+	"role_last_holder":        "perubahan ini membuat tidak ada user aktif yang memegang %s",
+
+	// klaim akun alumni
+	"account_already_linked":  "akun sudah tertaut ke data alumni",
+	"alumni_not_linked":       "akun belum terhubung ke data alumni",
+	"nim_not_found":           "NIM tidak ditemukan",
+	"invalid_claim_id":        "ID klaim tidak valid",
+	"claim_not_found":         "klaim tidak ditemukan",
+	"claim_not_pending":       "klaim tidak menunggu verifikasi",
+	"claim_code_expired":      "kode verifikasi kedaluwarsa, buat klaim baru",
+	"claim_code_invalid":      "kode verifikasi salah",
+	"claim_not_disputable":    "klaim tidak bisa diajukan ke admin",
+	"claim_already_decided":   "klaim sudah diputuskan",
+	"claim_already_pending":   "kode verifikasi untuk klaim ini masih berlaku, cek email alumni",
+	"claim_rate_limited":      "terlalu banyak klaim baru, coba lagi nanti",
+	"claim_too_many_attempts": "terlalu banyak percobaan, ajukan review admin",
+	"claim_save_failed":       "gagal menyimpan klaim",
+	"claim_code_failed":       "gagal membuat kode verifikasi",
+	"claim_code_email_failed": "gagal mengirim kode verifikasi",
+	"claim_fetch_failed":      "gagal mengambil data klaim",
+	"account_link_failed":     "gagal menautkan akun",
+	"claim_review_required":   "klaim perlu direview admin",
+	"claim_code_sent":         "kode verifikasi dikirim ke %s",
+	"claim_escalated":         "alumni sudah diklaim akun lain, klaim diteruskan ke admin",
+	"claim_disputed":          "klaim diteruskan ke admin",
+	"account_linked":          "akun berhasil ditautkan ke data alumni",
+	"claim_approved":          "klaim disetujui",
+	"claim_rejected":          "klaim ditolak",
+
+	// alumni
+	"invalid_alumni_id":             "ID alumni tidak valid",
+	"invalid_angkatan":              "Angkatan tidak valid",
+	"alumni_not_found":              "Alumni tidak ditemukan",
+	"alumni_duplicate":              "NIM atau email alumni sudah dipakai",
+	"alumni_fetch_failed":           "Gagal mengambil data alumni",
+	"alumni_count_failed":           "Gagal menghitung data alumni",
+	"alumni_create_failed":          "Gagal menambah alumni",
+	"alumni_update_failed":          "Gagal mengupdate alumni",
+	"alumni_delete_failed":          "Gagal menghapus alumni",
+	"alumni_fetched":                "Data alumni berhasil diambil",
+	"alumni_with_pekerjaan_fetched": "Data alumni dan pekerjaan berhasil diambil",
+	"alumni_created":                "Alumni berhasil ditambahkan",
+	"alumni_updated":                "Alumni berhasil diupdate",
+	"alumni_deleted":                "Alumni berhasil dihapus",
+
+	// pekerjaan
+	"invalid_pekerjaan_id":            "ID pekerjaan tidak valid",
+	"pekerjaan_not_found":             "Pekerjaan tidak ditemukan",
+	"pekerjaan_fetch_failed":          "Gagal mengambil data pekerjaan",
+	"pekerjaan_count_failed":          "Gagal menghitung data pekerjaan",
+	"pekerjaan_create_failed":         "Gagal menambah pekerjaan",
+	"pekerjaan_update_failed":         "Gagal mengupdate pekerjaan",
+	"pekerjaan_delete_failed":         "Gagal menghapus pekerjaan",
+	"pekerjaan_restore_failed":        "Gagal me-restore pekerjaan",
+	"pekerjaan_hard_delete_failed":    "Gagal menghapus permanen pekerjaan",
+	"pekerjaan_update_forbidden":      "Kamu tidak punya izin mengubah pekerjaan ini",
+	"pekerjaan_delete_forbidden":      "Kamu tidak memiliki izin menghapus pekerjaan ini",
+	"pekerjaan_restore_forbidden":     "Kamu tidak punya izin untuk me-restore pekerjaan ini",
+	"pekerjaan_hard_delete_forbidden": "Kamu tidak memiliki izin menghapus permanen pekerjaan ini",
+	"unknown_action":                  "aksi tidak dikenal",
+	"pekerjaan_fetched":               "Data pekerjaan berhasil diambil",
+	"pekerjaan_by_alumni_fetched":     "Data pekerjaan untuk alumni berhasil diambil",
+	"pekerjaan_created":               "Pekerjaan berhasil ditambahkan",
+	"pekerjaan_updated":               "Pekerjaan berhasil diupdate",
+	"pekerjaan_deleted":               "Pekerjaan berhasil dihapus",
+	"pekerjaan_trashed":               "Pekerjaan berhasil dihapus (soft delete)",
+	"pekerjaan_restored":              "Pekerjaan berhasil di-restore",
+	"pekerjaan_hard_deleted":          "Pekerjaan berhasil dihapus permanen",
+	"trash_empty":                     "Tidak ada data pekerjaan di trash",
+	"trash_fetched":                   "Data pekerjaan trash berhasil diambil",
+}
@@ -35,3 +35,16 @@ type AlumniPekerjaan struct {
     TahunMulai     int    `json:"tanggal_mulai_kerja"`
     TahunSelesai   int    `json:"tanggal_selesai_kerja"`
 }
+
+// AlumniRequest adalah field alumni yang bisa diisi saat tambah/ubah.
+// NIM hanya dipakai saat tambah.
+type AlumniRequest struct {
+	NIM        string  `json:"nim"`
+	Nama       string  `json:"nama"`
+	Jurusan    string  `json:"jurusan"`
+	Angkatan   int     `json:"angkatan"`
+	TahunLulus int     `json:"tahun_lulus"`
+	Email      string  `json:"email"`
+	NoTelepon  *string `json:"no_telepon"`
+	Alamat     *string `json:"alamat"`
+}
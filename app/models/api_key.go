@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// APIKeyPrefix menandai API key supaya mudah dikenali (misal oleh secret scanner)
+const APIKeyPrefix = "alk_"
+
+// APIKey merepresentasikan tabel api_keys. Key hanya disimpan sebagai hash;
+// Prefix (beberapa karakter awal) disimpan terbuka untuk identifikasi.
+// Key bertindak atas nama UserID (akun layanan) dengan permission = Scopes.
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	UserID     int        `json:"user_id"`
+	Username   string     `json:"username"`
+	Role       string     `json:"role"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedBy  *int       `json:"created_by"` // nil jika admin pembuatnya sudah dihapus
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name"`
+	UserID        int      `json:"user_id"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// CreateAPIKeyResponse berisi key mentah; hanya ditampilkan sekali saat dibuat
+type CreateAPIKeyResponse struct {
+	Key  string `json:"key"`
+	Data APIKey `json:"data"`
+}
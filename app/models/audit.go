@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// AuditEntry merepresentasikan tabel audit_logs: aksi tulis yang dilakukan
+// admin (ActorID) saat impersonasi sebagai user lain (UserID)
+type AuditEntry struct {
+	ID        int       `json:"id"`
+	ActorID   int       `json:"actor_id"`
+	UserID    int       `json:"user_id"`
+	SessionID string    `json:"session_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
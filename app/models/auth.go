@@ -1,13 +1,25 @@
 package models
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type User struct {
 	ID       int    `json:"id"`
 	AlumniID *int    `json:"alumni_id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
-	Role     string `json:"role"`
+	// false sampai user membuka link verifikasi; sebelum itu akun hanya bisa membaca
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
+	Disabled bool   `json:"disabled"`
+	// diset admin lewat force-password-reset; login ditolak sampai password direset
+	PasswordResetRequired bool      `json:"password_reset_required"`
+	// bahasa respons API pilihan user (lihat i18n); kosong = ikut Accept-Language
+	Locale    string    `json:"locale"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type LoginRequest struct {
@@ -16,13 +28,40 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	User  User   `json:"user"`
-	Token string `json:"token"`
+	User         User   `json:"user"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // detik sampai access token kedaluwarsa
+	// hanya terisi sekali, saat 2FA baru saja diaktifkan
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 type JWTClaims struct {
+	UserID      int      `json:"user_id"`
+	Username    string   `json:"username"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"perms,omitempty"`
+	SessionID   string   `json:"sid"`
+	Purpose     string   `json:"purpose,omitempty"` // kosong = access token biasa, lihat TokenPurpose*
+	// EmailVerified false = akun hanya boleh akses endpoint baca
+	EmailVerified bool `json:"ev"`
+	// Email hanya diisi pada token verifikasi email
+	Email string `json:"email,omitempty"`
+	// Locale adalah bahasa pilihan user; baru berubah setelah token di-refresh
+	Locale string `json:"locale,omitempty"`
+	// Actor terisi saat admin melakukan impersonasi: token berlaku sebagai
+	// UserID, tapi aksi tulisnya dicatat atas nama Actor (RFC 8693 "act")
+	Actor *ActorClaim `json:"act,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// ActorClaim adalah admin asli di balik token impersonasi
+type ActorClaim struct {
+	Subject  string `json:"sub"`
 	UserID   int    `json:"user_id"`
 	Username string `json:"username"`
-	Role     string `json:"role"`
-	jwt.RegisteredClaims
 }
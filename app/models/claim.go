@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Status klaim akun alumni
+const (
+	ClaimPending  = "pending"  // menunggu kode verifikasi dari email alumni
+	ClaimVerified = "verified" // kode benar, akun sudah tertaut
+	ClaimDisputed = "disputed" // perlu review admin (alumni sudah diklaim / email tidak bisa diakses)
+	ClaimApproved = "approved" // disetujui admin, akun sudah tertaut
+	ClaimRejected = "rejected"
+)
+
+// AlumniClaim merepresentasikan tabel alumni_claims
+type AlumniClaim struct {
+	ID            int        `json:"id"`
+	UserID        int        `json:"user_id"`
+	AlumniID      int        `json:"alumni_id"`
+	NIM           string     `json:"nim"`
+	Status        string     `json:"status"`
+	Note          *string    `json:"note,omitempty"`
+	CodeHash      *string    `json:"-"`
+	CodeExpiresAt *time.Time `json:"code_expires_at,omitempty"`
+	Attempts      int        `json:"attempts"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy    *int       `json:"resolved_by,omitempty"`
+}
+
+type CreateClaimRequest struct {
+	NIM  string `json:"nim"`
+	Note string `json:"note"`
+}
+
+type VerifyClaimRequest struct {
+	Code string `json:"code"`
+}
+
+type ClaimNoteRequest struct {
+	Note string `json:"note"`
+}
@@ -0,0 +1,5 @@
+package models
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	LockScopeAccount = "account"
+	LockScopeIP      = "ip"
+)
+
+// LoginThrottle merepresentasikan tabel login_throttles (per akun atau per IP)
+type LoginThrottle struct {
+	Scope       string     `json:"scope"`
+	Key         string     `json:"key"`
+	Failures    int        `json:"failures"`
+	Lockouts    int        `json:"lockouts"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+// LockoutEvent merepresentasikan tabel lockout_events
+type LockoutEvent struct {
+	ID          int        `json:"id"`
+	Scope       string     `json:"scope"`
+	Key         string     `json:"key"`
+	UserID      *int       `json:"user_id,omitempty"`
+	Event       string     `json:"event"` // "locked" atau "unlocked"
+	Failures    int        `json:"failures"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	IP          string     `json:"ip"`
+	ActorID     *int       `json:"actor_id,omitempty"` // admin yang melakukan unlock
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// MeResponse adalah profil lengkap user yang sedang login
+type MeResponse struct {
+	User      User             `json:"user"`
+	Alumni    *Alumni          `json:"alumni"`
+	Pekerjaan *PekerjaanAlumni `json:"pekerjaan"` // pekerjaan yang masih berjalan
+}
+
+// UpdateMeRequest: field nil tidak diubah. no_telepon & alamat hanya
+// berlaku jika akun sudah tertaut ke data alumni. locale "" menghapus
+// preferensi bahasa (kembali ikut Accept-Language).
+type UpdateMeRequest struct {
+	Username  *string `json:"username"`
+	Email     *string `json:"email"`
+	NoTelepon *string `json:"no_telepon"`
+	Alamat    *string `json:"alamat"`
+	Locale    *string `json:"locale"`
+}
+
+// UpdateEmailRequest dipakai PUT /api/me/email, satu-satunya perubahan
+// profil yang boleh sebelum email terverifikasi
+type UpdateEmailRequest struct {
+	Email string `json:"email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
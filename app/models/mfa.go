@@ -0,0 +1,49 @@
+package models
+
+// Purpose token; masing-masing ditandatangani dengan audience sendiri
+// (lihat utils.JWT) sehingga token satu purpose tidak bisa dipakai di tempat lain
+const (
+	// TokenPurposeAccess adalah access token biasa (claim purpose kosong)
+	TokenPurposeAccess = ""
+	// TokenPurposeImpersonation adalah access token admin atas nama user lain
+	TokenPurposeImpersonation = "impersonation"
+	// TokenPurposeMFA dipakai challenge token langkah kedua login
+	TokenPurposeMFA = "mfa"
+)
+
+// TokenPurposeVerifyEmail dipakai token di link verifikasi email
+const TokenPurposeVerifyEmail = "verify_email"
+
+// TOTPState adalah kolom totp_* pada tabel users
+type TOTPState struct {
+	Secret   *string
+	Enabled  bool
+	LastStep int64
+}
+
+type TOTPSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// MFAChallengeResponse dikembalikan Login jika user wajib/sudah memakai 2FA
+type MFAChallengeResponse struct {
+	MFARequired        bool   `json:"mfa_required"`
+	EnrollmentRequired bool   `json:"enrollment_required"`
+	ChallengeToken     string `json:"challenge_token"`
+	ExpiresIn          int    `json:"expires_in"`
+}
+
+type MFAChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type MFAVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
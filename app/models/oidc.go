@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// OIDCLoginState merepresentasikan tabel oidc_login_states: data yang harus
+// dibawa dari redirect login ke callback (state disimpan sebagai hash).
+type OIDCLoginState struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	// LinkUserID terisi jika alur dimulai user yang sudah login untuk
+	// menautkan identitas SSO ke akunnya (bukan login)
+	LinkUserID *int
+}
+
+// OIDCPendingLink merepresentasikan tabel oidc_pending_links: identitas SSO
+// hasil alur penautan yang menunggu konfirmasi pemilik akun
+type OIDCPendingLink struct {
+	CodeHash  string
+	UserID    int
+	Issuer    string
+	Subject   string
+	ExpiresAt time.Time
+}
+
+// OIDCLinkConfirmRequest: link_code dari respons callback alur penautan
+type OIDCLinkConfirmRequest struct {
+	LinkCode string `json:"link_code"`
+}
+
+// UserIdentity merepresentasikan tabel user_identities: akun SSO (issuer + sub)
+// yang terhubung ke baris users
+type UserIdentity struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	UserID    int       `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
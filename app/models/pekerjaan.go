@@ -22,3 +22,18 @@ type PekerjaanAlumni struct {
 	DeletedBy string      `json:"deleted_by"`
 }
 
+
+// PekerjaanRequest adalah field pekerjaan yang bisa diisi saat tambah/ubah.
+// AlumniID hanya dipakai saat tambah.
+type PekerjaanRequest struct {
+	AlumniID            int        `json:"alumni_id"`
+	NamaPerusahaan      string     `json:"nama_perusahaan"`
+	PosisiJabatan       string     `json:"posisi_jabatan"`
+	BidangIndustri      string     `json:"bidang_industri"`
+	LokasiKerja         string     `json:"lokasi_kerja"`
+	GajiRange           *string    `json:"gaji_range"`
+	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string     `json:"status_pekerjaan"`
+	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
+}
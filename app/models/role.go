@@ -0,0 +1,76 @@
+package models
+
+import "strings"
+
+// Nama permission yang dicek oleh middleware.Require
+const (
+	PermAlumniWrite         = "alumni:write"          // tambah/ubah/hapus data alumni
+	PermPekerjaanWrite      = "pekerjaan:write"       // kelola pekerjaan milik alumni mana pun
+	PermPekerjaanHardDelete = "pekerjaan:hard_delete" // hapus permanen pekerjaan alumni mana pun
+	PermPekerjaanTrashAll   = "pekerjaan:trash_all"   // lihat trash pekerjaan semua alumni
+	PermUsersManage         = "users:manage"          // kelola akun, role, dan admin
+	PermUsersImpersonate    = "users:impersonate"     // login sebagai user lain untuk support
+)
+
+// IsPrivileged melaporkan apakah perms memuat permission pengelola akun
+// (users:*, termasuk users:manage yang juga mengelola role). Pemegangnya
+// wajib 2FA dan tidak boleh diimpersonasi.
+func IsPrivileged(perms []string) bool {
+	for _, p := range perms {
+		if PrivilegedPermission(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrivilegedPermission melaporkan apakah satu permission termasuk pengelola akun
+func PrivilegedPermission(p string) bool {
+	return strings.HasPrefix(p, "users:")
+}
+
+// Role merepresentasikan tabel roles beserta isi role_permissions
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// Permission merepresentasikan tabel permissions
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// BuiltinPermissions adalah daftar permission yang dikenal aplikasi. Baris
+// di tabel permissions diisi lewat migrasi: permission baru di sini juga
+// butuh migrasi baru (lihat 0016_seed_builtin_permissions).
+func BuiltinPermissions() []Permission {
+	return []Permission{
+		{PermAlumniWrite, "Tambah, ubah, dan hapus data alumni"},
+		{PermPekerjaanWrite, "Kelola pekerjaan milik alumni mana pun"},
+		{PermPekerjaanHardDelete, "Hapus permanen pekerjaan alumni mana pun"},
+		{PermPekerjaanTrashAll, "Lihat trash pekerjaan semua alumni"},
+		{PermUsersManage, "Kelola akun user, role, dan admin"},
+		{PermUsersImpersonate, "Melihat aplikasi sebagai user lain (impersonasi)"},
+	}
+}
+
+// BuiltinRoles adalah role bawaan yang dibuat saat aplikasi start jika belum
+// ada; Permissions hanya diberikan saat role baru dibuat
+func BuiltinRoles() []Role {
+	all := make([]string, 0)
+	for _, p := range BuiltinPermissions() {
+		all = append(all, p.Name)
+	}
+	return []Role{
+		{Name: "admin", Description: "Administrator", Permissions: all},
+		{Name: "staff", Description: "Staf fakultas", Permissions: []string{PermAlumniWrite}},
+		{Name: "user", Description: "Alumni", Permissions: []string{}},
+	}
+}
+
+type RoleRequest struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
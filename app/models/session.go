@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Session merepresentasikan tabel auth_sessions.
+// Satu sesi = satu keluarga refresh token hasil rotasi dari satu kali login.
+type Session struct {
+	ID        string     `json:"id"`
+	UserID    int        `json:"user_id"`
+	ActorID   *int       `json:"actor_id,omitempty"` // admin pembuka sesi impersonasi
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// RefreshToken merepresentasikan tabel refresh_tokens (yang disimpan hanya hash-nya)
+type RefreshToken struct {
+	ID             int
+	SessionID      string
+	UserID         int
+	TokenHash      string
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	CreatedAt      time.Time
+	SessionRevoked bool
+}
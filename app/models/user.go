@@ -18,3 +18,13 @@ type AdminCreateUserRequest struct {
 	Password string `json:"password"`
 	Role     string `json:"role"` // admin/user
 }
+
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// LinkAlumniRequest: alumni_id null = lepas tautan
+type LinkAlumniRequest struct {
+	AlumniID *int `json:"alumni_id"`
+}
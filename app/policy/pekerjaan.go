@@ -0,0 +1,43 @@
+package policy
+
+import "go_clean/app/models"
+
+type Action string
+
+const (
+	PekerjaanUpdate     Action = "update"
+	PekerjaanDelete     Action = "delete"
+	PekerjaanRestore    Action = "restore"
+	PekerjaanHardDelete Action = "hard_delete"
+)
+
+// Pekerjaan memutuskan apakah principal boleh melakukan action pada pekerjaan.
+// Aturannya: pemegang permission boleh untuk pekerjaan siapa pun, selain itu
+// hanya pemilik (akun yang terhubung ke alumni_id pekerjaan). Akun yang belum
+// terhubung ke data alumni selalu ditolak.
+func Pekerjaan(p Principal, action Action, res *models.PekerjaanAlumni) Decision {
+	var perm, code string
+	switch action {
+	case PekerjaanUpdate:
+		perm, code = models.PermPekerjaanWrite, "pekerjaan_update_forbidden"
+	case PekerjaanDelete:
+		perm, code = models.PermPekerjaanWrite, "pekerjaan_delete_forbidden"
+	case PekerjaanRestore:
+		perm, code = models.PermPekerjaanWrite, "pekerjaan_restore_forbidden"
+	case PekerjaanHardDelete:
+		perm, code = models.PermPekerjaanHardDelete, "pekerjaan_hard_delete_forbidden"
+	default:
+		return deny("unknown_action")
+	}
+
+	if p.Can(perm) {
+		return allow()
+	}
+	if p.AlumniID == nil {
+		return deny("alumni_not_linked")
+	}
+	if !p.Owns(res.AlumniID) {
+		return deny(code)
+	}
+	return allow()
+}
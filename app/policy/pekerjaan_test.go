@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	"go_clean/app/i18n"
+	"go_clean/app/models"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestPekerjaanPolicy(t *testing.T) {
+	owned := &models.PekerjaanAlumni{ID: 1, AlumniID: 10}
+
+	owner := Principal{UserID: 1, Role: "user", AlumniID: intPtr(10)}
+	stranger := Principal{UserID: 2, Role: "user", AlumniID: intPtr(20)}
+	unlinked := Principal{UserID: 3, Role: "user"}
+	writer := Principal{UserID: 4, Role: "staff", Permissions: []string{models.PermPekerjaanWrite}}
+	admin := Principal{UserID: 5, Role: "admin", Permissions: []string{
+		models.PermPekerjaanWrite, models.PermPekerjaanHardDelete,
+	}}
+
+	tests := []struct {
+		name      string
+		principal Principal
+		action    Action
+		allowed   bool
+	}{
+		{"owner update", owner, PekerjaanUpdate, true},
+		{"owner delete", owner, PekerjaanDelete, true},
+		{"owner restore", owner, PekerjaanRestore, true},
+		{"owner hard delete", owner, PekerjaanHardDelete, true},
+
+		{"stranger update", stranger, PekerjaanUpdate, false},
+		{"stranger delete", stranger, PekerjaanDelete, false},
+		{"stranger restore", stranger, PekerjaanRestore, false},
+		{"stranger hard delete", stranger, PekerjaanHardDelete, false},
+
+		// dulu lolos karena AlumniID nil tidak dicek
+		{"unlinked update", unlinked, PekerjaanUpdate, false},
+		{"unlinked delete", unlinked, PekerjaanDelete, false},
+		{"unlinked restore", unlinked, PekerjaanRestore, false},
+		{"unlinked hard delete", unlinked, PekerjaanHardDelete, false},
+
+		{"writer update", writer, PekerjaanUpdate, true},
+		{"writer delete", writer, PekerjaanDelete, true},
+		{"writer restore", writer, PekerjaanRestore, true},
+		{"writer hard delete", writer, PekerjaanHardDelete, false},
+
+		{"admin update", admin, PekerjaanUpdate, true},
+		{"admin hard delete", admin, PekerjaanHardDelete, true},
+
+		{"unknown action", admin, Action("publish"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Pekerjaan(tt.principal, tt.action, owned)
+			if got.Allowed != tt.allowed {
+				t.Fatalf("Allowed = %v, want %v (code: %q)", got.Allowed, tt.allowed, got.Code)
+			}
+			if !got.Allowed && !i18n.Has(got.Code) {
+				t.Fatalf("denied decision must carry a catalog code, got %q", got.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"context"
+
+	"go_clean/app/models"
+)
+
+// Principal adalah user yang sedang login beserta data yang dibutuhkan policy
+type Principal struct {
+	UserID      int
+	Username    string
+	Role        string
+	AlumniID    *int
+	Permissions []string
+	// ActorID adalah admin asli saat impersonasi, selain itu sama dengan UserID.
+	// Dipakai untuk jejak seperti deleted_by.
+	ActorID int
+}
+
+func (p Principal) Can(perm string) bool {
+	for _, have := range p.Permissions {
+		if have == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Owns true jika akun terhubung ke alumni pemilik resource
+func (p Principal) Owns(alumniID int) bool {
+	return p.AlumniID != nil && *p.AlumniID == alumniID
+}
+
+// Decision adalah hasil evaluasi policy; Code diisi saat ditolak dan menjadi
+// kunci pesan di katalog i18n
+type Decision struct {
+	Allowed bool
+	Code    string
+}
+
+func allow() Decision { return Decision{Allowed: true} }
+
+func deny(code string) Decision { return Decision{Code: code} }
+
+// UserFinder dipakai Authorizer untuk memuat baris users milik principal
+type UserFinder interface {
+	GetUserByID(ctx context.Context, id int) (*models.User, error)
+}
+
+// Authorizer memuat principal, lalu service menyerahkan keputusan ke fungsi
+// policy murni (lihat pekerjaan.go).
+type Authorizer struct {
+	Users UserFinder
+}
+
+// Principal membangun Principal dari identitas token (user, permission, dan
+// actor asli saat impersonasi) dan baris users.
+func (a *Authorizer) Principal(ctx context.Context, userID int, perms []string, actorID int) (*Principal, error) {
+	u, err := a.Users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{
+		UserID:      u.ID,
+		Username:    u.Username,
+		Role:        u.Role,
+		AlumniID:    u.AlumniID,
+		Permissions: perms,
+		ActorID:     actorID,
+	}, nil
+}
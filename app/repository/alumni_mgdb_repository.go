@@ -2,8 +2,8 @@ package repository
 
 import (
 	"context"
-	"fmt"
 	"go_clean/app/models"
+	"strconv"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -46,55 +46,62 @@ func (r *AlumniMongoRepository) FindAll(ctx context.Context) ([]models.AlumniMon
 	return alumniList, nil
 }
 
+// alumniFilter memilih filter _id jika id ObjectID hex, atau alumni_id jika
+// numerik; ok false jika id bukan keduanya
+func alumniFilter(id string) (filter bson.M, ok bool) {
+	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+		return bson.M{"_id": objID}, true
+	}
+	if alumniID, err := strconv.Atoi(id); err == nil {
+		return bson.M{"alumni_id": alumniID}, true
+	}
+	return nil, false
+}
+
 // Get By ID (bisa _id Mongo atau alumni_id custom)
 func (r *AlumniMongoRepository) FindByID(ctx context.Context, id string) (*models.AlumniMongo, error) {
-	var result models.AlumniMongo
-
-	// coba dulu convert ke ObjectID
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err == nil {
-		err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
-		if err == nil {
-			return &result, nil
-		}
+	filter, ok := alumniFilter(id)
+	if !ok {
+		return nil, mongo.ErrNoDocuments
 	}
-
-	// fallback ke alumni_id numerik
-	err = r.collection.FindOne(ctx, bson.M{"alumni_id": id}).Decode(&result)
-	if err != nil {
-		return nil, fmt.Errorf("data tidak ditemukan")
+	var result models.AlumniMongo
+	if err := r.collection.FindOne(ctx, filter).Decode(&result); err != nil {
+		return nil, err
 	}
 	return &result, nil
 }
 
 // Update
 func (r *AlumniMongoRepository) Update(ctx context.Context, id string, data *models.AlumniMongo) (*models.AlumniMongo, error) {
-	filter := bson.M{}
-
-	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
-		filter = bson.M{"_id": objID}
-	} else {
-		filter = bson.M{"alumni_id": id}
+	filter, ok := alumniFilter(id)
+	if !ok {
+		return nil, mongo.ErrNoDocuments
 	}
 
 	update := bson.M{"$set": data}
-	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update())
+	res, err := r.collection.UpdateOne(ctx, filter, update, options.Update())
 	if err != nil {
 		return nil, err
 	}
+	if res.MatchedCount == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
 	return data, nil
 }
 
 // Delete
 func (r *AlumniMongoRepository) Delete(ctx context.Context, id string) error {
-	filter := bson.M{}
-
-	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
-		filter = bson.M{"_id": objID}
-	} else {
-		filter = bson.M{"alumni_id": id}
+	filter, ok := alumniFilter(id)
+	if !ok {
+		return mongo.ErrNoDocuments
 	}
 
-	_, err := r.collection.DeleteOne(ctx, filter)
-	return err
+	res, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
@@ -1,15 +1,15 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"go_clean/app/models"
-	"go_clean/database"
 	"time"
 )
 
 type AlumniRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 // AlumniSortable returns a slice of sortable field names for alumni
@@ -17,8 +17,8 @@ func AlumniSortable() []string {
 	return []string{"nim", "nama", "jurusan", "angkatan", "email"}
 }
 
-func (r *AlumniRepository) GetAllAlumni() ([]models.Alumni, error) {
-	rows, err := r.DB.Query("SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at FROM alumni ORDER BY created_at DESC")
+func (r *AlumniRepository) GetAllAlumni(ctx context.Context) ([]models.Alumni, error) {
+	rows, err := r.DB.QueryContext(ctx, "SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at FROM alumni ORDER BY created_at DESC")
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,7 @@ func (r *AlumniRepository) GetAllAlumni() ([]models.Alumni, error) {
 	return alumniList, nil
 }
 
-func (r *AlumniRepository) GetAlumniAndPekerjaan(nim int) (*models.AlumniPekerjaan, error) {
+func (r *AlumniRepository) GetAlumniAndPekerjaan(ctx context.Context, nim int) (*models.AlumniPekerjaan, error) {
 	query := `
         SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email,
         p.nama_perusahaan, p.posisi_jabatan, p.tanggal_mulai_kerja, p.tanggal_selesai_kerja
@@ -44,7 +44,7 @@ func (r *AlumniRepository) GetAlumniAndPekerjaan(nim int) (*models.AlumniPekerja
         WHERE a.id = $1
 
     `
-	row := r.DB.QueryRow(query, nim)
+	row := r.DB.QueryRowContext(ctx, query, nim)
 
 	var result models.AlumniPekerjaan
 	err := row.Scan(
@@ -59,27 +59,27 @@ func (r *AlumniRepository) GetAlumniAndPekerjaan(nim int) (*models.AlumniPekerja
 	return &result, nil
 }
 
-func (r *AlumniRepository) GetAlumniByID(id int) (*models.Alumni, error) {
+func (r *AlumniRepository) GetAlumniByID(ctx context.Context, id int) (*models.Alumni, error) {
 	var a models.Alumni
-	err := r.DB.QueryRow("SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at FROM alumni WHERE id = $1", id).Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt)
+	err := r.DB.QueryRowContext(ctx, "SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at FROM alumni WHERE id = $1", id).Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
 	return &a, nil
 }
 
-func (r *AlumniRepository) GetAlumniByAngkatan(angkatan int) (*models.AlumniAngkatan, error) {
+func (r *AlumniRepository) GetAlumniByAngkatan(ctx context.Context, angkatan int) (*models.AlumniAngkatan, error) {
 	jumlahalumni := &models.AlumniAngkatan{Angkatan: angkatan}
-	err := r.DB.QueryRow("SELECT COUNT(*) FROM alumni WHERE angkatan = $1", angkatan).Scan(&jumlahalumni.Jumlah)
+	err := r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM alumni WHERE angkatan = $1", angkatan).Scan(&jumlahalumni.Jumlah)
 	if err != nil {
 		return nil, err
 	}
 	return jumlahalumni, nil
 }
 
-func (r *AlumniRepository) CreateAlumni(alumni *models.Alumni) (int, error) {
+func (r *AlumniRepository) CreateAlumni(ctx context.Context, alumni *models.Alumni) (int, error) {
 	var id int
-	err := r.DB.QueryRow(
+	err := r.DB.QueryRowContext(ctx, 
 		"INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id",
 		alumni.NIM, alumni.Nama, alumni.Jurusan, alumni.Angkatan, alumni.TahunLulus, alumni.Email, alumni.NoTelepon, alumni.Alamat, time.Now(), time.Now(),
 	).Scan(&id)
@@ -89,8 +89,8 @@ func (r *AlumniRepository) CreateAlumni(alumni *models.Alumni) (int, error) {
 	return id, nil
 }
 
-func (r *AlumniRepository) UpdateAlumni(id int, alumni *models.Alumni) (int64, error) {
-	result, err := r.DB.Exec(
+func (r *AlumniRepository) UpdateAlumni(ctx context.Context, id int, alumni *models.Alumni) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, 
 		"UPDATE alumni SET nama = $1, jurusan = $2, angkatan = $3, tahun_lulus = $4, email = $5, no_telepon = $6, alamat = $7, updated_at = $8 WHERE id = $9",
 		alumni.Nama, alumni.Jurusan, alumni.Angkatan, alumni.TahunLulus, alumni.Email, alumni.NoTelepon, alumni.Alamat, time.Now(), id,
 	)
@@ -100,8 +100,8 @@ func (r *AlumniRepository) UpdateAlumni(id int, alumni *models.Alumni) (int64, e
 	return result.RowsAffected()
 }
 
-func (r *AlumniRepository) DeleteAlumni(id int) (int64, error) {
-	result, err := r.DB.Exec("DELETE FROM alumni WHERE id = $1", id)
+func (r *AlumniRepository) DeleteAlumni(ctx context.Context, id int) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM alumni WHERE id = $1", id)
 	if err != nil {
 		return 0, err
 	}
@@ -124,7 +124,7 @@ func sanitizeOrderAlumni(o string) string {
 	return "ASC"
 }
 
-func ListAlumniRepo(search, sortBy, order string, limit, offset int) ([]models.Alumni, error) {
+func (r *AlumniRepository) ListAlumniRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.Alumni, error) {
 	// Sanitasi sort & order biar aman dari SQL injection via fmt.Sprintf
 	sortBy = sanitizeAlumniSort(sortBy)
 	order = sanitizeOrderAlumni(order)
@@ -137,7 +137,7 @@ func ListAlumniRepo(search, sortBy, order string, limit, offset int) ([]models.A
         LIMIT $2 OFFSET $3
     `, sortBy, order)
 
-	rows, err := database.DB.Query(query, "%"+search+"%", limit, offset)
+	rows, err := r.DB.QueryContext(ctx, query, "%"+search+"%", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +154,9 @@ func ListAlumniRepo(search, sortBy, order string, limit, offset int) ([]models.A
 	return items, rows.Err()
 }
 
-func CountAlumniRepo(search string) (int, error) {
+func (r *AlumniRepository) CountAlumniRepo(ctx context.Context, search string) (int, error) {
 	var total int
-	err := database.DB.QueryRow(`
+	err := r.DB.QueryRowContext(ctx, `
         SELECT COUNT(*)
         FROM alumni
         WHERE (nama ILIKE $1 OR CAST(nim AS TEXT) ILIKE $1)
@@ -166,3 +166,21 @@ func CountAlumniRepo(search string) (int, error) {
 	}
 	return total, nil
 }
+
+func (r *AlumniRepository) GetAlumniByNIM(ctx context.Context, nim string) (*models.Alumni, error) {
+	var a models.Alumni
+	err := r.DB.QueryRowContext(ctx, "SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at FROM alumni WHERE nim = $1", nim).Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// UpdateContact hanya mengubah data kontak (dipakai alumni untuk profilnya sendiri)
+func (r *AlumniRepository) UpdateContact(ctx context.Context, id int, noTelepon, alamat *string) error {
+	_, err := r.DB.ExecContext(ctx, 
+		"UPDATE alumni SET no_telepon = $1, alamat = $2, updated_at = $3 WHERE id = $4",
+		noTelepon, alamat, time.Now(), id,
+	)
+	return err
+}
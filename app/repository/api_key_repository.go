@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyRepository struct {
+	DB DBTX
+}
+
+const apiKeyColumns = `k.id, k.name, k.prefix, k.key_hash, k.user_id, u.username, u.role, k.scopes,
+	k.expires_at, k.last_used_at, k.created_by, k.created_at, k.revoked_at`
+
+// activeAPIKeyColumns sama dengan apiKeyColumns, tetapi scopes dipangkas ke
+// permission role pemilik saat ini: key tidak ikut turun saat role diturunkan
+// kecuali scopes-nya dihitung ulang di setiap request.
+const activeAPIKeyColumns = `k.id, k.name, k.prefix, k.key_hash, k.user_id, u.username, u.role,
+	ARRAY(
+		SELECT s FROM unnest(k.scopes) AS s
+		WHERE s IN (SELECT permission FROM role_permissions WHERE role_name = u.role)
+	),
+	k.expires_at, k.last_used_at, k.created_by, k.created_at, k.revoked_at`
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var k models.APIKey
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.KeyHash, &k.UserID, &k.Username, &k.Role,
+		pq.Array(&k.Scopes), &k.ExpiresAt, &k.LastUsedAt, &k.CreatedBy, &k.CreatedAt, &k.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, k *models.APIKey) error {
+	k.CreatedAt = time.Now()
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO api_keys (name, prefix, key_hash, user_id, scopes, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, k.Name, k.Prefix, k.KeyHash, k.UserID, pq.Array(k.Scopes), k.ExpiresAt, k.CreatedBy, k.CreatedAt).Scan(&k.ID)
+}
+
+func (r *APIKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		ORDER BY k.created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *k)
+	}
+	return keys, rows.Err()
+}
+
+// GetActiveAPIKey mencari key berdasarkan hash. Key yang dicabut, kedaluwarsa,
+// atau milik akun nonaktif dianggap tidak ada (sql.ErrNoRows). Scopes yang
+// dikembalikan hanya yang masih dimiliki role pemiliknya.
+func (r *APIKeyRepository) GetActiveAPIKey(ctx context.Context, hash string) (*models.APIKey, error) {
+	return scanAPIKey(r.DB.QueryRowContext(ctx, `
+		SELECT `+activeAPIKeyColumns+`
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1
+		  AND k.revoked_at IS NULL
+		  AND k.expires_at > $2
+		  AND NOT u.disabled
+	`, hash, time.Now()))
+}
+
+// TouchAPIKey mencatat last_used_at; ditulis paling sering sekali per menit per key
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id int) error {
+	now := time.Now()
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE api_keys SET last_used_at = $1
+		WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)
+	`, now, id, now.Add(-time.Minute))
+	return err
+}
+
+// Revoke mengembalikan false jika key tidak ada atau sudah dicabut
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
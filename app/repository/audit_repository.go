@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+	"time"
+)
+
+type AuditRepository struct {
+	DB DBTX
+}
+
+func (r *AuditRepository) Record(ctx context.Context, e models.AuditEntry) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO audit_logs (actor_id, user_id, session_id, method, path, status, ip, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, e.ActorID, e.UserID, e.SessionID, e.Method, e.Path, e.Status, e.IP, time.Now())
+	return err
+}
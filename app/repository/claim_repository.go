@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+	"time"
+)
+
+type ClaimRepository struct {
+	DB DBTX
+}
+
+const claimColumns = `id, user_id, alumni_id, nim, status, note, code_hash, code_expires_at, attempts, created_at, resolved_at, resolved_by`
+
+func scanClaim(row rowScanner) (*models.AlumniClaim, error) {
+	var cl models.AlumniClaim
+	err := row.Scan(&cl.ID, &cl.UserID, &cl.AlumniID, &cl.NIM, &cl.Status, &cl.Note, &cl.CodeHash,
+		&cl.CodeExpiresAt, &cl.Attempts, &cl.CreatedAt, &cl.ResolvedAt, &cl.ResolvedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &cl, nil
+}
+
+func (r *ClaimRepository) Create(ctx context.Context, cl *models.AlumniClaim) error {
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO alumni_claims (user_id, alumni_id, nim, status, note, code_hash, code_expires_at, attempts, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 0, $8)
+		RETURNING id, created_at
+	`, cl.UserID, cl.AlumniID, cl.NIM, cl.Status, cl.Note, cl.CodeHash, cl.CodeExpiresAt, time.Now()).Scan(&cl.ID, &cl.CreatedAt)
+}
+
+func (r *ClaimRepository) GetByID(ctx context.Context, id int) (*models.AlumniClaim, error) {
+	return scanClaim(r.DB.QueryRowContext(ctx, `SELECT `+claimColumns+` FROM alumni_claims WHERE id = $1`, id))
+}
+
+func (r *ClaimRepository) listWhere(ctx context.Context, where string, arg interface{}) ([]models.AlumniClaim, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+claimColumns+` FROM alumni_claims WHERE `+where+` ORDER BY created_at DESC`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claims := []models.AlumniClaim{}
+	for rows.Next() {
+		cl, err := scanClaim(rows)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, *cl)
+	}
+	return claims, rows.Err()
+}
+
+func (r *ClaimRepository) GetByUserID(ctx context.Context, userID int) ([]models.AlumniClaim, error) {
+	return r.listWhere(ctx, "user_id = $1", userID)
+}
+
+func (r *ClaimRepository) GetByStatus(ctx context.Context, status string) ([]models.AlumniClaim, error) {
+	return r.listWhere(ctx, "status = $1", status)
+}
+
+// GetPending mengambil klaim pending terbaru user untuk alumni tertentu
+func (r *ClaimRepository) GetPending(ctx context.Context, userID, alumniID int) (*models.AlumniClaim, error) {
+	return scanClaim(r.DB.QueryRowContext(ctx, `
+		SELECT `+claimColumns+` FROM alumni_claims
+		WHERE user_id = $1 AND alumni_id = $2 AND status = $3
+		ORDER BY created_at DESC LIMIT 1
+	`, userID, alumniID, models.ClaimPending))
+}
+
+// CountSince menghitung klaim yang dibuat user sejak waktu tertentu
+func (r *ClaimRepository) CountSince(ctx context.Context, userID int, since time.Time) (int, error) {
+	var n int
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM alumni_claims WHERE user_id = $1 AND created_at > $2`, userID, since).Scan(&n)
+	return n, err
+}
+
+// RenewCode mengganti kode verifikasi klaim pending; attempts tidak di-reset
+// supaya batas percobaan tetap berlaku per klaim
+func (r *ClaimRepository) RenewCode(ctx context.Context, id int, codeHash string, expiresAt time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE alumni_claims SET code_hash = $1, code_expires_at = $2
+		WHERE id = $3 AND status = $4
+	`, codeHash, expiresAt, id, models.ClaimPending)
+	return err
+}
+
+// UseAttempt memakai satu jatah percobaan verifikasi secara atomik.
+// sql.ErrNoRows berarti jatah max sudah habis.
+func (r *ClaimRepository) UseAttempt(ctx context.Context, id, max int) (int, error) {
+	var attempts int
+	err := r.DB.QueryRowContext(ctx, `
+		UPDATE alumni_claims SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2
+		RETURNING attempts
+	`, id, max).Scan(&attempts)
+	return attempts, err
+}
+
+// UpdateStatus mengubah status klaim; kode verifikasi dihapus karena tidak dipakai lagi
+func (r *ClaimRepository) UpdateStatus(ctx context.Context, id int, status string, note *string, resolvedBy *int) error {
+	var resolvedAt *time.Time
+	if status != models.ClaimPending && status != models.ClaimDisputed {
+		now := time.Now()
+		resolvedAt = &now
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE alumni_claims
+		SET status = $1, note = COALESCE($2, note), resolved_at = $3, resolved_by = $4,
+		    code_hash = NULL, code_expires_at = NULL
+		WHERE id = $5
+	`, status, note, resolvedAt, resolvedBy, id)
+	return err
+}
+
+// LinkUser menautkan akun ke alumni sekaligus menutup klaim dalam satu transaksi.
+// Jika unlinkOthers true (keputusan admin), tautan akun lain ke alumni itu dilepas.
+func (r *ClaimRepository) LinkUser(ctx context.Context, claimID, userID, alumniID int, status string, resolvedBy *int, unlinkOthers bool) error {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if unlinkOthers {
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET alumni_id = NULL WHERE alumni_id = $1 AND id <> $2`, alumniID, userID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET alumni_id = $1 WHERE id = $2`, alumniID, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE alumni_claims
+		SET status = $1, resolved_at = $2, resolved_by = $3, code_hash = NULL, code_expires_at = NULL
+		WHERE id = $4
+	`, status, time.Now(), resolvedBy, claimID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
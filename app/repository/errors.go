@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// IsUniqueViolation true jika err berasal dari constraint UNIQUE (23505)
+func IsUniqueViolation(err error) bool {
+	return pqCode(err) == "23505"
+}
+
+// IsForeignKeyViolation true jika err berasal dari constraint FOREIGN KEY (23503)
+func IsForeignKeyViolation(err error) bool {
+	return pqCode(err) == "23503"
+}
+
+func pqCode(err error) pq.ErrorCode {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return ""
+	}
+	return pqErr.Code
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"go_clean/app/models"
+	"time"
+)
+
+type LoginThrottleRepository struct {
+	DB DBTX
+}
+
+// Get mengembalikan state throttle; baris yang belum ada dianggap bersih
+func (r *LoginThrottleRepository) Get(ctx context.Context, scope, key string) (*models.LoginThrottle, error) {
+	t := models.LoginThrottle{Scope: scope, Key: key}
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT failures, lockouts, locked_until
+		FROM login_throttles
+		WHERE scope = $1 AND key = $2
+	`, scope, key).Scan(&t.Failures, &t.Lockouts, &t.LockedUntil)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RegisterFailure menambah counter gagal login dan mengembalikan state terbaru
+func (r *LoginThrottleRepository) RegisterFailure(ctx context.Context, scope, key string, window time.Duration) (*models.LoginThrottle, error) {
+	t := models.LoginThrottle{Scope: scope, Key: key}
+	now := time.Now()
+	// gagal terakhir yang sudah lewat window dianggap kedaluwarsa: hitung ulang dari 1
+	err := r.DB.QueryRowContext(ctx, `
+		INSERT INTO login_throttles (scope, key, failures, lockouts, updated_at)
+		VALUES ($1, $2, 1, 0, $3)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET failures = CASE WHEN login_throttles.updated_at < $4 THEN 1 ELSE login_throttles.failures + 1 END,
+		    updated_at = $3
+		RETURNING failures, lockouts, locked_until
+	`, scope, key, now, now.Add(-window)).Scan(&t.Failures, &t.Lockouts, &t.LockedUntil)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Lock mengunci scope/key sampai waktu tertentu dan mereset counter gagal
+func (r *LoginThrottleRepository) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE login_throttles
+		SET failures = 0, lockouts = lockouts + 1, locked_until = $1, updated_at = $2
+		WHERE scope = $3 AND key = $4
+	`, until, time.Now(), scope, key)
+	return err
+}
+
+// Reset menghapus state throttle (login sukses atau unlock oleh admin)
+func (r *LoginThrottleRepository) Reset(ctx context.Context, scope, key string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM login_throttles WHERE scope = $1 AND key = $2`, scope, key)
+	return err
+}
+
+func (r *LoginThrottleRepository) LogEvent(ctx context.Context, e *models.LockoutEvent) error {
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO lockout_events (scope, key, user_id, event, failures, locked_until, ip, actor_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, e.Scope, e.Key, e.UserID, e.Event, e.Failures, e.LockedUntil, e.IP, e.ActorID, time.Now()).Scan(&e.ID)
+}
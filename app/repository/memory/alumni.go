@@ -0,0 +1,267 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+)
+
+// AlumniStore adalah repository.AlumniStore di atas DB
+type AlumniStore struct {
+	db *DB
+}
+
+var _ repository.AlumniStore = (*AlumniStore)(nil)
+
+func cloneAlumni(a models.Alumni) models.Alumni {
+	a.NoTelepon = cloneString(a.NoTelepon)
+	a.Alamat = cloneString(a.Alamat)
+	return a
+}
+
+// alumniRows mengembalikan salinan semua baris yang lolos filter; pemanggil
+// harus memegang db.mu
+func (s *AlumniStore) alumniRows(keep func(a models.Alumni) bool) []models.Alumni {
+	var rows []models.Alumni
+	for _, a := range s.db.alumni {
+		if keep == nil || keep(a) {
+			rows = append(rows, cloneAlumni(a))
+		}
+	}
+	return rows
+}
+
+// checkUnique meniru constraint UNIQUE (nim) dan UNIQUE (email); pemanggil
+// harus memegang db.mu
+func (s *AlumniStore) checkUnique(id int, a *models.Alumni) error {
+	for _, other := range s.db.alumni {
+		if other.ID == id {
+			continue
+		}
+		if other.NIM == a.NIM {
+			return uniqueViolation("alumni_nim_key", fmt.Sprintf("Key (nim)=(%s) already exists.", a.NIM))
+		}
+		if other.Email == a.Email {
+			return uniqueViolation("alumni_email_key", fmt.Sprintf("Key (email)=(%s) already exists.", a.Email))
+		}
+	}
+	return nil
+}
+
+func (s *AlumniStore) GetAllAlumni(ctx context.Context) ([]models.Alumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	rows := s.alumniRows(nil)
+	slices.SortFunc(rows, func(a, b models.Alumni) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return rows, nil
+}
+
+// GetAlumniAndPekerjaan meniru JOIN alumni-pekerjaan_alumni: baris pertama
+// (pekerjaan dengan id terkecil) yang dikembalikan
+func (s *AlumniStore) GetAlumniAndPekerjaan(ctx context.Context, id int) (*models.AlumniPekerjaan, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	a, ok := s.db.alumni[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	var (
+		p     models.PekerjaanAlumni
+		found bool
+	)
+	for _, row := range s.db.pekerjaan {
+		if row.AlumniID == id && (!found || row.ID < p.ID) {
+			p, found = row, true
+		}
+	}
+	if !found {
+		return nil, sql.ErrNoRows
+	}
+	res := &models.AlumniPekerjaan{
+		ID: a.ID, NIM: a.NIM, Nama: a.Nama, Jurusan: a.Jurusan,
+		Angkatan: a.Angkatan, TahunLulus: a.TahunLulus, Email: a.Email,
+		NamaPerusahaan: p.NamaPerusahaan, Posisi: p.PosisiJabatan,
+		TahunMulai: p.TanggalMulaiKerja.Year(),
+	}
+	if p.TanggalSelesaiKerja != nil {
+		res.TahunSelesai = p.TanggalSelesaiKerja.Year()
+	}
+	return res, nil
+}
+
+func (s *AlumniStore) GetAlumniByID(ctx context.Context, id int) (*models.Alumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	a, ok := s.db.alumni[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	a = cloneAlumni(a)
+	return &a, nil
+}
+
+func (s *AlumniStore) GetAlumniByNIM(ctx context.Context, nim string) (*models.Alumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	for _, a := range s.db.alumni {
+		if a.NIM == nim {
+			a = cloneAlumni(a)
+			return &a, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *AlumniStore) GetAlumniByAngkatan(ctx context.Context, angkatan int) (*models.AlumniAngkatan, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	res := &models.AlumniAngkatan{Angkatan: angkatan}
+	for _, a := range s.db.alumni {
+		if a.Angkatan == angkatan {
+			res.Jumlah++
+		}
+	}
+	return res, nil
+}
+
+func (s *AlumniStore) CreateAlumni(ctx context.Context, alumni *models.Alumni) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if err := s.checkUnique(0, alumni); err != nil {
+		return 0, err
+	}
+	s.db.alumniSeq++
+	a := cloneAlumni(*alumni)
+	a.ID = s.db.alumniSeq
+	a.CreatedAt, a.UpdatedAt = time.Now(), time.Now()
+	s.db.alumni[a.ID] = a
+	return a.ID, nil
+}
+
+func (s *AlumniStore) UpdateAlumni(ctx context.Context, id int, alumni *models.Alumni) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	a, ok := s.db.alumni[id]
+	if !ok {
+		return 0, nil
+	}
+	// NIM tidak ikut diubah, sama seperti query UPDATE-nya
+	a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus = alumni.Nama, alumni.Jurusan, alumni.Angkatan, alumni.TahunLulus
+	a.Email = alumni.Email
+	a.NoTelepon, a.Alamat = cloneString(alumni.NoTelepon), cloneString(alumni.Alamat)
+	if err := s.checkUnique(id, &a); err != nil {
+		return 0, err
+	}
+	a.UpdatedAt = time.Now()
+	s.db.alumni[id] = a
+	return 1, nil
+}
+
+func (s *AlumniStore) UpdateContact(ctx context.Context, id int, noTelepon, alamat *string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	a, ok := s.db.alumni[id]
+	if !ok {
+		return nil
+	}
+	a.NoTelepon, a.Alamat = cloneString(noTelepon), cloneString(alamat)
+	a.UpdatedAt = time.Now()
+	s.db.alumni[id] = a
+	return nil
+}
+
+// DeleteAlumni juga menghapus pekerjaannya (ON DELETE CASCADE)
+func (s *AlumniStore) DeleteAlumni(ctx context.Context, id int) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if _, ok := s.db.alumni[id]; !ok {
+		return 0, nil
+	}
+	delete(s.db.alumni, id)
+	for pid, p := range s.db.pekerjaan {
+		if p.AlumniID == id {
+			delete(s.db.pekerjaan, pid)
+		}
+	}
+	return 1, nil
+}
+
+// matchAlumni meniru (nama ILIKE %search% OR nim ILIKE %search%)
+func matchAlumni(search string) func(a models.Alumni) bool {
+	search = strings.ToLower(search)
+	return func(a models.Alumni) bool {
+		return strings.Contains(strings.ToLower(a.Nama), search) ||
+			strings.Contains(strings.ToLower(a.NIM), search)
+	}
+}
+
+func compareAlumni(field string) func(a, b models.Alumni) int {
+	switch field {
+	case "nim":
+		return func(a, b models.Alumni) int { return cmp.Compare(a.NIM, b.NIM) }
+	case "nama":
+		return func(a, b models.Alumni) int { return cmp.Compare(a.Nama, b.Nama) }
+	case "jurusan":
+		return func(a, b models.Alumni) int { return cmp.Compare(a.Jurusan, b.Jurusan) }
+	case "angkatan":
+		return func(a, b models.Alumni) int { return cmp.Compare(a.Angkatan, b.Angkatan) }
+	case "email":
+		return func(a, b models.Alumni) int { return cmp.Compare(a.Email, b.Email) }
+	case "created_at":
+		return func(a, b models.Alumni) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case "updated_at":
+		return func(a, b models.Alumni) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	default:
+		return func(a, b models.Alumni) int { return cmp.Compare(a.ID, b.ID) }
+	}
+}
+
+// ListAlumniRepo hanya mengisi kolom yang di-SELECT query aslinya (id, nama, nim, angkatan)
+func (s *AlumniStore) ListAlumniRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.Alumni, error) {
+	s.db.mu.RLock()
+	rows := s.alumniRows(matchAlumni(search))
+	s.db.mu.RUnlock()
+
+	byField := compareAlumni(sortBy)
+	desc := order == "desc" || order == "DESC"
+	slices.SortFunc(rows, func(a, b models.Alumni) int {
+		c := byField(a, b)
+		if desc {
+			c = -c
+		}
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+
+	var items []models.Alumni
+	for _, a := range page(rows, limit, offset) {
+		items = append(items, models.Alumni{ID: a.ID, Nama: a.Nama, NIM: a.NIM, Angkatan: a.Angkatan})
+	}
+	return items, nil
+}
+
+func (s *AlumniStore) CountAlumniRepo(ctx context.Context, search string) (int, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	return len(s.alumniRows(matchAlumni(search))), nil
+}
+
+// page meniru LIMIT/OFFSET
+func page[T any](rows []T, limit, offset int) []T {
+	if offset >= len(rows) {
+		return nil
+	}
+	rows = rows[max(offset, 0):]
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
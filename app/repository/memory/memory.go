@@ -0,0 +1,133 @@
+// Package memory berisi implementasi in-memory dari interface penyimpanan di
+// package repository (AlumniStore, PekerjaanStore, AlumniMongoStore,
+// PekerjaanMongoStore, StoreTxRunner). Semua tipe aman dipakai dari banyak
+// goroutine sekaligus. Ditujukan untuk test: HTTP API alumni dan pekerjaan
+// (Postgres dan Mongo) bisa dijalankan tanpa database. Repository lain
+// (users, sessions, roles, ...) tidak punya versi in-memory, sehingga
+// service yang membutuhkannya tidak bisa dirakit dari package ini.
+//
+// Perilaku dibuat semirip mungkin dengan implementasi database: data yang
+// tidak ada dikembalikan sebagai sql.ErrNoRows, pelanggaran UNIQUE dan
+// FOREIGN KEY sebagai *pq.Error dengan kode SQLSTATE yang sama, dan kolom
+// yang tidak di-SELECT oleh query aslinya dibiarkan kosong.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+
+	"github.com/lib/pq"
+)
+
+// DB menyimpan tabel alumni dan pekerjaan_alumni. Keduanya berbagi satu kunci
+// karena saling terkait (JOIN, foreign key ON DELETE CASCADE).
+type DB struct {
+	mu        sync.RWMutex
+	alumni    map[int]models.Alumni
+	pekerjaan map[int]models.PekerjaanAlumni
+	alumniSeq int
+	pkjSeq    int
+
+	// txMu membuat transaksi berjalan satu per satu (setara SERIALIZABLE)
+	txMu sync.Mutex
+}
+
+func New() *DB {
+	return &DB{
+		alumni:    make(map[int]models.Alumni),
+		pekerjaan: make(map[int]models.PekerjaanAlumni),
+	}
+}
+
+// Alumni mengembalikan AlumniStore di atas db
+func (db *DB) Alumni() *AlumniStore { return &AlumniStore{db: db} }
+
+// Pekerjaan mengembalikan PekerjaanStore di atas db
+func (db *DB) Pekerjaan() *PekerjaanStore { return &PekerjaanStore{db: db} }
+
+// Stores mengembalikan repository.Stores di atas db
+func (db *DB) Stores() repository.Stores {
+	return repository.Stores{Alumni: db.Alumni(), Pekerjaan: db.Pekerjaan()}
+}
+
+// DoStores memenuhi repository.StoreTxRunner. Transaksi dijalankan bergantian; jika fn
+// mengembalikan error, isi tabel dikembalikan ke kondisi sebelum fn. opts
+// diabaikan. Tulis di luar Do yang terjadi bersamaan dengan transaksi yang
+// di-rollback ikut hilang, jadi jangan campur keduanya di test konkuren.
+func (db *DB) DoStores(ctx context.Context, opts *sql.TxOptions, fn func(s repository.Stores) error) error {
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	snap := db.snapshot()
+	if err := fn(db.Stores()); err != nil {
+		db.restore(snap)
+		return err
+	}
+	return nil
+}
+
+type snapshot struct {
+	alumni    map[int]models.Alumni
+	pekerjaan map[int]models.PekerjaanAlumni
+}
+
+func (db *DB) snapshot() snapshot {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	s := snapshot{
+		alumni:    make(map[int]models.Alumni, len(db.alumni)),
+		pekerjaan: make(map[int]models.PekerjaanAlumni, len(db.pekerjaan)),
+	}
+	for id, a := range db.alumni {
+		s.alumni[id] = a
+	}
+	for id, p := range db.pekerjaan {
+		s.pekerjaan[id] = p
+	}
+	return s
+}
+
+// restore mengembalikan isi tabel. Sequence tidak dikembalikan, sama seperti
+// SERIAL di Postgres yang tidak ikut rollback.
+func (db *DB) restore(s snapshot) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.alumni = s.alumni
+	db.pekerjaan = s.pekerjaan
+}
+
+func uniqueViolation(constraint, detail string) error {
+	return &pq.Error{
+		Code:       "23505",
+		Message:    fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		Detail:     detail,
+		Constraint: constraint,
+	}
+}
+
+func foreignKeyViolation(constraint, detail string) error {
+	return &pq.Error{
+		Code:       "23503",
+		Message:    fmt.Sprintf("insert or update violates foreign key constraint %q", constraint),
+		Detail:     detail,
+		Constraint: constraint,
+	}
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+var _ repository.StoreTxRunner = (*DB)(nil)
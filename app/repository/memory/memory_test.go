@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+
+	"github.com/lib/pq"
+)
+
+func alumni(nim string) *models.Alumni {
+	return &models.Alumni{NIM: nim, Nama: "Alumni " + nim, Jurusan: "Informatika", Email: nim + "@kampus.ac.id"}
+}
+
+func TestDoRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	boom := errors.New("gagal di tengah")
+
+	err := db.DoStores(ctx, nil, func(r repository.Stores) error {
+		id, err := r.Alumni.CreateAlumni(ctx, alumni("1"))
+		if err != nil {
+			return err
+		}
+		if _, err := r.Pekerjaan.CreatePekerjaan(ctx, &models.PekerjaanAlumni{AlumniID: id}); err != nil {
+			return err
+		}
+		return boom
+	})
+	if err != boom {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if n, _ := db.Alumni().CountAlumniRepo(ctx, ""); n != 0 {
+		t.Fatalf("alumni tersisa %d setelah rollback", n)
+	}
+	if all, _ := db.Pekerjaan().GetAllPekerjaan(ctx); len(all) != 0 {
+		t.Fatalf("pekerjaan tersisa %d setelah rollback", len(all))
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	a := db.Alumni()
+
+	id, err := a.CreateAlumni(ctx, alumni("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pqErr *pq.Error
+	if _, err := a.CreateAlumni(ctx, alumni("1")); !errors.As(err, &pqErr) || pqErr.Code != "23505" {
+		t.Fatalf("NIM duplikat: err = %v, want unique_violation", err)
+	}
+	if _, err := db.Pekerjaan().CreatePekerjaan(ctx, &models.PekerjaanAlumni{AlumniID: id + 1}); !errors.As(err, &pqErr) || pqErr.Code != "23503" {
+		t.Fatalf("alumni_id tidak ada: err = %v, want foreign_key_violation", err)
+	}
+
+	pid, err := db.Pekerjaan().CreatePekerjaan(ctx, &models.PekerjaanAlumni{AlumniID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ON DELETE CASCADE
+	if _, err := a.DeleteAlumni(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Pekerjaan().GetPekerjaanByID(ctx, pid); err != sql.ErrNoRows {
+		t.Fatalf("pekerjaan setelah alumni dihapus: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	id, err := db.Alumni().CreateAlumni(ctx, alumni("0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	ids := make([]int, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = db.DoStores(ctx, nil, func(r repository.Stores) error {
+				pid, err := r.Pekerjaan.CreatePekerjaan(ctx, &models.PekerjaanAlumni{AlumniID: id, NamaPerusahaan: "PT"})
+				ids[i] = pid
+				return err
+			})
+			_, _ = db.Pekerjaan().ListPekerjaanRepo(ctx, "pt", "id", "asc", 10, 0)
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool)
+	for _, pid := range ids {
+		if pid == 0 || seen[pid] {
+			t.Fatalf("ID pekerjaan kosong/duplikat: %v", ids)
+		}
+		seen[pid] = true
+	}
+	if total, _ := db.Pekerjaan().CountPekerjaanRepo(ctx, ""); total != n {
+		t.Fatalf("total = %d, want %d", total, n)
+	}
+}
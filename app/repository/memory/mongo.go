@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AlumniMongoStore adalah repository.AlumniMongoStore: koleksi alumni in-memory
+type AlumniMongoStore struct {
+	mu   sync.RWMutex
+	docs []models.AlumniMongo // urutan insert, seperti natural order koleksi
+}
+
+var _ repository.AlumniMongoStore = (*AlumniMongoStore)(nil)
+
+func NewAlumniMongoStore() *AlumniMongoStore {
+	return &AlumniMongoStore{}
+}
+
+// index mencari dokumen dengan _id = id, atau alumni_id = id jika id numerik,
+// seperti filter repository Mongo; pemanggil harus memegang mu
+func (s *AlumniMongoStore) index(id string) int {
+	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+		for i, d := range s.docs {
+			if d.ID == objID {
+				return i
+			}
+		}
+		return -1
+	}
+	if alumniID, err := strconv.Atoi(id); err == nil {
+		for i, d := range s.docs {
+			if d.AlumniID == alumniID {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (s *AlumniMongoStore) Create(ctx context.Context, data *models.AlumniMongo) (*models.AlumniMongo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data.ID.IsZero() {
+		data.ID = primitive.NewObjectID()
+	}
+	s.docs = append(s.docs, *data)
+	return data, nil
+}
+
+func (s *AlumniMongoStore) FindAll(ctx context.Context) ([]models.AlumniMongo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.docs) == 0 {
+		return nil, nil
+	}
+	return append([]models.AlumniMongo(nil), s.docs...), nil
+}
+
+func (s *AlumniMongoStore) FindByID(ctx context.Context, id string) (*models.AlumniMongo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := s.index(id)
+	if i < 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	d := s.docs[i]
+	return &d, nil
+}
+
+// Update meniru $set seluruh dokumen
+func (s *AlumniMongoStore) Update(ctx context.Context, id string, data *models.AlumniMongo) (*models.AlumniMongo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(id)
+	if i < 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	d := *data
+	d.ID = s.docs[i].ID
+	s.docs[i] = d
+	return data, nil
+}
+
+func (s *AlumniMongoStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(id)
+	if i < 0 {
+		return mongo.ErrNoDocuments
+	}
+	s.docs = append(s.docs[:i], s.docs[i+1:]...)
+	return nil
+}
+
+// PekerjaanMongoStore adalah repository.PekerjaanMongoStore: koleksi pekerjaan in-memory
+type PekerjaanMongoStore struct {
+	mu   sync.RWMutex
+	docs []models.PekerjaanMongo
+}
+
+var _ repository.PekerjaanMongoStore = (*PekerjaanMongoStore)(nil)
+
+func NewPekerjaanMongoStore() *PekerjaanMongoStore {
+	return &PekerjaanMongoStore{}
+}
+
+// index mencari dokumen dengan _id = objID; pemanggil harus memegang mu
+func (s *PekerjaanMongoStore) index(objID primitive.ObjectID) int {
+	for i, d := range s.docs {
+		if d.ID == objID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *PekerjaanMongoStore) Create(ctx context.Context, p *models.PekerjaanMongo) (*models.PekerjaanMongo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p.ID.IsZero() {
+		p.ID = primitive.NewObjectID()
+	}
+	s.docs = append(s.docs, *p)
+	return p, nil
+}
+
+func (s *PekerjaanMongoStore) FindAll(ctx context.Context) ([]models.PekerjaanMongo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.docs) == 0 {
+		return nil, nil
+	}
+	return append([]models.PekerjaanMongo(nil), s.docs...), nil
+}
+
+// FindByID mengembalikan error yang sama dengan driver: ObjectID tidak
+// valid atau mongo.ErrNoDocuments
+func (s *PekerjaanMongoStore) FindByID(ctx context.Context, id string) (*models.PekerjaanMongo, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := s.index(objID)
+	if i < 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	d := s.docs[i]
+	return &d, nil
+}
+
+func (s *PekerjaanMongoStore) FindByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanMongo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var list []models.PekerjaanMongo
+	for _, d := range s.docs {
+		if d.AlumniID == alumniID {
+			list = append(list, d)
+		}
+	}
+	return list, nil
+}
+
+// Update meniru $set seluruh dokumen lalu membaca ulang
+func (s *PekerjaanMongoStore) Update(ctx context.Context, id string, p *models.PekerjaanMongo) (*models.PekerjaanMongo, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	i := s.index(objID)
+	if i >= 0 {
+		d := *p
+		d.ID = objID
+		s.docs[i] = d
+	}
+	s.mu.Unlock()
+	if i < 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return s.FindByID(ctx, id)
+}
+
+func (s *PekerjaanMongoStore) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(objID)
+	if i < 0 {
+		return mongo.ErrNoDocuments
+	}
+	s.docs = append(s.docs[:i], s.docs[i+1:]...)
+	return nil
+}
@@ -0,0 +1,306 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+)
+
+// PekerjaanStore adalah repository.PekerjaanStore di atas DB
+type PekerjaanStore struct {
+	db *DB
+}
+
+var _ repository.PekerjaanStore = (*PekerjaanStore)(nil)
+
+func clonePekerjaan(p models.PekerjaanAlumni) models.PekerjaanAlumni {
+	p.GajiRange = cloneString(p.GajiRange)
+	p.DeskripsiPekerjaan = cloneString(p.DeskripsiPekerjaan)
+	if p.TanggalSelesaiKerja != nil {
+		t := *p.TanggalSelesaiKerja
+		p.TanggalSelesaiKerja = &t
+	}
+	if p.DeletedAt != nil {
+		t := *p.DeletedAt
+		p.DeletedAt = &t
+	}
+	return p
+}
+
+// withoutTrash mengosongkan kolom trash yang tidak di-SELECT sebagian besar
+// query (is_delete, deleted_at, deleted_by)
+func withoutTrash(p models.PekerjaanAlumni) models.PekerjaanAlumni {
+	p.IsDeleted, p.DeletedAt, p.DeletedBy = false, nil, ""
+	return p
+}
+
+// pekerjaanRows mengembalikan salinan semua baris yang lolos filter;
+// pemanggil harus memegang db.mu
+func (s *PekerjaanStore) pekerjaanRows(keep func(p models.PekerjaanAlumni) bool) []models.PekerjaanAlumni {
+	var rows []models.PekerjaanAlumni
+	for _, p := range s.db.pekerjaan {
+		if keep == nil || keep(p) {
+			rows = append(rows, clonePekerjaan(p))
+		}
+	}
+	return rows
+}
+
+func notDeleted(p models.PekerjaanAlumni) bool { return !p.IsDeleted }
+
+func newestFirst(a, b models.PekerjaanAlumni) int {
+	return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+}
+
+// matchPekerjaan meniru (nama_perusahaan ILIKE %search% OR posisi_jabatan ILIKE %search%)
+func matchPekerjaan(search string) func(p models.PekerjaanAlumni) bool {
+	search = strings.ToLower(search)
+	return func(p models.PekerjaanAlumni) bool {
+		return !p.IsDeleted && (strings.Contains(strings.ToLower(p.NamaPerusahaan), search) ||
+			strings.Contains(strings.ToLower(p.PosisiJabatan), search))
+	}
+}
+
+func comparePekerjaan(field string) func(a, b models.PekerjaanAlumni) int {
+	switch field {
+	case "alumni_id":
+		return func(a, b models.PekerjaanAlumni) int { return cmp.Compare(a.AlumniID, b.AlumniID) }
+	case "nama_perusahaan":
+		return func(a, b models.PekerjaanAlumni) int { return cmp.Compare(a.NamaPerusahaan, b.NamaPerusahaan) }
+	case "posisi_jabatan":
+		return func(a, b models.PekerjaanAlumni) int { return cmp.Compare(a.PosisiJabatan, b.PosisiJabatan) }
+	case "tanggal_mulai_kerja":
+		return func(a, b models.PekerjaanAlumni) int { return a.TanggalMulaiKerja.Compare(b.TanggalMulaiKerja) }
+	case "tanggal_selesai_kerja":
+		// NULL di urutan terakhir untuk ASC, seperti Postgres
+		return func(a, b models.PekerjaanAlumni) int {
+			switch {
+			case a.TanggalSelesaiKerja == nil && b.TanggalSelesaiKerja == nil:
+				return 0
+			case a.TanggalSelesaiKerja == nil:
+				return 1
+			case b.TanggalSelesaiKerja == nil:
+				return -1
+			}
+			return a.TanggalSelesaiKerja.Compare(*b.TanggalSelesaiKerja)
+		}
+	case "created_at":
+		return func(a, b models.PekerjaanAlumni) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case "updated_at":
+		return func(a, b models.PekerjaanAlumni) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	default:
+		return func(a, b models.PekerjaanAlumni) int { return cmp.Compare(a.ID, b.ID) }
+	}
+}
+
+func (s *PekerjaanStore) ListPekerjaanRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	rows := s.pekerjaanRows(matchPekerjaan(search))
+	s.db.mu.RUnlock()
+
+	byField := comparePekerjaan(sortBy)
+	desc := order == "desc" || order == "DESC"
+	slices.SortFunc(rows, func(a, b models.PekerjaanAlumni) int {
+		c := byField(a, b)
+		if desc {
+			c = -c
+		}
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+
+	var items []models.PekerjaanAlumni
+	for _, p := range page(rows, limit, offset) {
+		items = append(items, withoutTrash(p))
+	}
+	return items, nil
+}
+
+func (s *PekerjaanStore) CountPekerjaanRepo(ctx context.Context, search string) (int, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	return len(s.pekerjaanRows(matchPekerjaan(search))), nil
+}
+
+func (s *PekerjaanStore) GetAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	rows := s.pekerjaanRows(notDeleted)
+	slices.SortFunc(rows, newestFirst)
+	return rows, nil
+}
+
+// GetPekerjaanByID juga mengembalikan pekerjaan yang sudah di trash
+func (s *PekerjaanStore) GetPekerjaanByID(ctx context.Context, id int) (*models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	p, ok := s.db.pekerjaan[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	p = withoutTrash(clonePekerjaan(p))
+	return &p, nil
+}
+
+func (s *PekerjaanStore) GetPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	rows := s.pekerjaanRows(func(p models.PekerjaanAlumni) bool { return p.AlumniID == alumniID })
+	slices.SortFunc(rows, func(a, b models.PekerjaanAlumni) int {
+		return b.TanggalMulaiKerja.Compare(a.TanggalMulaiKerja)
+	})
+	for i := range rows {
+		rows[i] = withoutTrash(rows[i])
+	}
+	return rows, nil
+}
+
+func (s *PekerjaanStore) GetCurrentPekerjaanByAlumniID(ctx context.Context, alumniID int) (*models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	rows := s.pekerjaanRows(func(p models.PekerjaanAlumni) bool {
+		return p.AlumniID == alumniID && !p.IsDeleted && p.TanggalSelesaiKerja == nil
+	})
+	if len(rows) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	p := slices.MaxFunc(rows, func(a, b models.PekerjaanAlumni) int {
+		return a.TanggalMulaiKerja.Compare(b.TanggalMulaiKerja)
+	})
+	p = withoutTrash(p)
+	return &p, nil
+}
+
+// CreatePekerjaan menolak alumni_id yang tidak ada (FOREIGN KEY ke alumni)
+func (s *PekerjaanStore) CreatePekerjaan(ctx context.Context, p *models.PekerjaanAlumni) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if _, ok := s.db.alumni[p.AlumniID]; !ok {
+		return 0, foreignKeyViolation("pekerjaan_alumni_alumni_id_fkey",
+			fmt.Sprintf("Key (alumni_id)=(%d) is not present in table \"alumni\".", p.AlumniID))
+	}
+	s.db.pkjSeq++
+	row := withoutTrash(clonePekerjaan(*p))
+	row.ID = s.db.pkjSeq
+	row.CreatedAt, row.UpdatedAt = time.Now(), time.Now()
+	s.db.pekerjaan[row.ID] = row
+	return row.ID, nil
+}
+
+func (s *PekerjaanStore) UpdatePekerjaan(ctx context.Context, id int, p *models.PekerjaanAlumni) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row, ok := s.db.pekerjaan[id]
+	if !ok {
+		return 0, nil
+	}
+	in := clonePekerjaan(*p)
+	// alumni_id dan kolom trash tidak ikut diubah, sama seperti query UPDATE-nya
+	row.NamaPerusahaan, row.PosisiJabatan, row.BidangIndustri = in.NamaPerusahaan, in.PosisiJabatan, in.BidangIndustri
+	row.LokasiKerja, row.GajiRange = in.LokasiKerja, in.GajiRange
+	row.TanggalMulaiKerja, row.TanggalSelesaiKerja = in.TanggalMulaiKerja, in.TanggalSelesaiKerja
+	row.StatusPekerjaan, row.DeskripsiPekerjaan = in.StatusPekerjaan, in.DeskripsiPekerjaan
+	row.UpdatedAt = time.Now()
+	s.db.pekerjaan[id] = row
+	return 1, nil
+}
+
+func (s *PekerjaanStore) SoftDeletePekerjaan(ctx context.Context, id int, deletedBy int) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row, ok := s.db.pekerjaan[id]
+	if !ok || row.IsDeleted {
+		return 0, nil
+	}
+	now := time.Now()
+	row.IsDeleted, row.DeletedAt, row.DeletedBy = true, &now, strconv.Itoa(deletedBy)
+	s.db.pekerjaan[id] = row
+	return 1, nil
+}
+
+func (s *PekerjaanStore) TrashAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	rows := s.pekerjaanRows(func(p models.PekerjaanAlumni) bool { return p.IsDeleted })
+	slices.SortFunc(rows, func(a, b models.PekerjaanAlumni) int {
+		return cmp.Or(b.DeletedAt.Compare(*a.DeletedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return rows, nil
+}
+
+// TrashPekerjaanByAlumniID mengisi is_delete tapi tidak deleted_at/deleted_by
+func (s *PekerjaanStore) TrashPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	rows := s.pekerjaanRows(func(p models.PekerjaanAlumni) bool { return p.IsDeleted && p.AlumniID == alumniID })
+	slices.SortFunc(rows, newestFirst)
+	for i := range rows {
+		rows[i].DeletedAt, rows[i].DeletedBy = nil, ""
+	}
+	return rows, nil
+}
+
+func (s *PekerjaanStore) IsPekerjaanOwnedByUser(ctx context.Context, pekerjaanID, alumniID int) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	p, ok := s.db.pekerjaan[pekerjaanID]
+	return ok && p.AlumniID == alumniID, nil
+}
+
+func (s *PekerjaanStore) IsTrashedPekerjaanOwnedByUser(ctx context.Context, pekerjaanID, alumniID int) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	p, ok := s.db.pekerjaan[pekerjaanID]
+	return ok && p.AlumniID == alumniID && p.IsDeleted, nil
+}
+
+func (s *PekerjaanStore) RestorePekerjaanByID(ctx context.Context, id int) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.pekerjaan[id]; ok {
+		s.db.pekerjaan[id] = withoutTrash(row)
+	}
+	return nil
+}
+
+// HardDeletePekerjaanByID hanya menghapus pekerjaan yang sudah di trash
+func (s *PekerjaanStore) HardDeletePekerjaanByID(ctx context.Context, id int) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.pekerjaan[id]; ok && row.IsDeleted {
+		delete(s.db.pekerjaan, id)
+	}
+	return nil
+}
+
+func trashedBefore(before time.Time) func(p models.PekerjaanAlumni) bool {
+	return func(p models.PekerjaanAlumni) bool {
+		return p.IsDeleted && p.DeletedAt != nil && p.DeletedAt.Before(before)
+	}
+}
+
+func (s *PekerjaanStore) CountTrashedPekerjaanBefore(ctx context.Context, before time.Time) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	return int64(len(s.pekerjaanRows(trashedBefore(before)))), nil
+}
+
+func (s *PekerjaanStore) PurgeTrashedPekerjaanBefore(ctx context.Context, before time.Time) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var n int64
+	for id, p := range s.db.pekerjaan {
+		if trashedBefore(before)(p) {
+			delete(s.db.pekerjaan, id)
+			n++
+		}
+	}
+	return n, nil
+}
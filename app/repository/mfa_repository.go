@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+	"time"
+)
+
+type MFARepository struct {
+	DB DBTX
+}
+
+func (r *MFARepository) GetTOTP(ctx context.Context, userID int) (*models.TOTPState, error) {
+	var st models.TOTPState
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT totp_secret, totp_enabled, totp_last_step
+		FROM users
+		WHERE id = $1
+	`, userID).Scan(&st.Secret, &st.Enabled, &st.LastStep)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// SetTOTPSecret menyimpan secret baru yang belum aktif sampai dikonfirmasi
+func (r *MFARepository) SetTOTPSecret(ctx context.Context, userID int, secret string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET totp_secret = $1, totp_enabled = FALSE, totp_last_step = 0
+		WHERE id = $2
+	`, secret, userID)
+	return err
+}
+
+func (r *MFARepository) EnableTOTP(ctx context.Context, userID int) error {
+	_, err := r.DB.ExecContext(ctx, `UPDATE users SET totp_enabled = TRUE WHERE id = $1`, userID)
+	return err
+}
+
+func (r *MFARepository) DisableTOTP(ctx context.Context, userID int) error {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET totp_secret = NULL, totp_enabled = FALSE, totp_last_step = 0
+		WHERE id = $1
+	`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UseTOTPStep mencatat langkah waktu yang sudah dipakai. Mengembalikan false
+// jika langkah itu (atau yang lebih baru) sudah pernah dipakai: kode replay.
+func (r *MFARepository) UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET totp_last_step = $1
+		WHERE id = $2 AND totp_last_step < $1
+	`, step, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ReplaceRecoveryCodes mengganti semua recovery code user dengan hash yang baru
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID int, hashes []string) error {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, h := range hashes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO recovery_codes (user_id, code_hash, created_at)
+			VALUES ($1, $2, $3)
+		`, userID, h, now); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ConsumeRecoveryCode memakai satu recovery code; false jika tidak ada/sudah dipakai
+func (r *MFARepository) ConsumeRecoveryCode(ctx context.Context, userID int, hash string) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE recovery_codes SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`, time.Now(), userID, hash)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
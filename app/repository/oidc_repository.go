@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+	"time"
+)
+
+type OIDCRepository struct {
+	DB DBTX
+}
+
+func (r *OIDCRepository) CreateState(ctx context.Context, st models.OIDCLoginState) error {
+	// sekalian bersihkan state kedaluwarsa yang tidak pernah kembali ke callback
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM oidc_login_states WHERE expires_at < $1`, time.Now()); err != nil {
+		return err
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, expires_at, link_user_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`, st.StateHash, st.Nonce, st.CodeVerifier, st.ExpiresAt, st.LinkUserID)
+	return err
+}
+
+// ConsumeState menghapus state dan mengembalikannya; sql.ErrNoRows jika
+// tidak ada atau sudah kedaluwarsa. State hanya bisa dipakai sekali.
+func (r *OIDCRepository) ConsumeState(ctx context.Context, stateHash string) (*models.OIDCLoginState, error) {
+	var st models.OIDCLoginState
+	err := r.DB.QueryRowContext(ctx, `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1 AND expires_at > $2
+		RETURNING state_hash, nonce, code_verifier, expires_at, link_user_id
+	`, stateHash, time.Now()).Scan(&st.StateHash, &st.Nonce, &st.CodeVerifier, &st.ExpiresAt, &st.LinkUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func (r *OIDCRepository) GetIdentityUserID(ctx context.Context, issuer, subject string) (int, error) {
+	var userID int
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT user_id FROM user_identities WHERE issuer = $1 AND subject = $2
+	`, issuer, subject).Scan(&userID)
+	return userID, err
+}
+
+func (r *OIDCRepository) LinkIdentity(ctx context.Context, issuer, subject string, userID int) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO user_identities (issuer, subject, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (issuer, subject) DO NOTHING
+	`, issuer, subject, userID, time.Now())
+	return err
+}
+
+func (r *OIDCRepository) CreatePendingLink(ctx context.Context, l models.OIDCPendingLink) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM oidc_pending_links WHERE expires_at < $1`, time.Now()); err != nil {
+		return err
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO oidc_pending_links (code_hash, user_id, issuer, subject, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, l.CodeHash, l.UserID, l.Issuer, l.Subject, l.ExpiresAt)
+	return err
+}
+
+// ConsumePendingLink menghapus dan mengembalikan penautan milik userID;
+// sql.ErrNoRows jika code tidak ada, kedaluwarsa, atau milik user lain
+func (r *OIDCRepository) ConsumePendingLink(ctx context.Context, codeHash string, userID int) (*models.OIDCPendingLink, error) {
+	var l models.OIDCPendingLink
+	err := r.DB.QueryRowContext(ctx, `
+		DELETE FROM oidc_pending_links
+		WHERE code_hash = $1 AND user_id = $2 AND expires_at > $3
+		RETURNING code_hash, user_id, issuer, subject, expires_at
+	`, codeHash, userID, time.Now()).Scan(&l.CodeHash, &l.UserID, &l.Issuer, &l.Subject, &l.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type PasswordResetRepository struct {
+	DB DBTX
+}
+
+// Create menyimpan token reset baru dan membatalkan token lama user yang belum dipakai
+func (r *PasswordResetRepository) Create(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE password_reset_tokens SET used_at = $1
+		WHERE user_id = $2 AND used_at IS NULL
+	`, now, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, userID, tokenHash, expiresAt, now); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Consume menandai token sudah dipakai dan mengembalikan user_id pemiliknya.
+// sql.ErrNoRows berarti token tidak ada, sudah dipakai, atau kedaluwarsa.
+func (r *PasswordResetRepository) Consume(ctx context.Context, tokenHash string) (int, error) {
+	var userID int
+	now := time.Now()
+	err := r.DB.QueryRowContext(ctx, `
+		UPDATE password_reset_tokens SET used_at = $1
+		WHERE token_hash = $2 AND used_at IS NULL AND expires_at > $1
+		RETURNING user_id
+	`, now, tokenHash).Scan(&userID)
+	return userID, err
+}
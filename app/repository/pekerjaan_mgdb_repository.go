@@ -73,10 +73,13 @@ func (r *PekerjaanMongoRepository) Update(ctx context.Context, id string, p *mod
 	if err != nil {
 		return nil, err
 	}
-	_, err = r.collection.UpdateByID(ctx, objID, bson.M{"$set": p})
+	res, err := r.collection.UpdateByID(ctx, objID, bson.M{"$set": p})
 	if err != nil {
 		return nil, err
 	}
+	if res.MatchedCount == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
 	return r.FindByID(ctx, id)
 }
 
@@ -85,6 +88,12 @@ func (r *PekerjaanMongoRepository) Delete(ctx context.Context, id string) error
 	if err != nil {
 		return err
 	}
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
-	return err
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
@@ -1,15 +1,15 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"database/sql"
 	"go_clean/app/models"
 	"time"
-	"go_clean/database"
 )
 
 type PekerjaanRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 
@@ -38,7 +38,7 @@ func sanitizeOrderPekerjaan(o string) string {
 
 // --- Fungsi utama untuk List & Count (mirip Alumni) ---
 
-func ListPekerjaanRepo(search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) {
+func (r *PekerjaanRepository) ListPekerjaanRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) {
 	sortBy = sanitizePekerjaanSort(sortBy)
 	order = sanitizeOrderPekerjaan(order)
 
@@ -52,7 +52,7 @@ func ListPekerjaanRepo(search, sortBy, order string, limit, offset int) ([]model
 		LIMIT $2 OFFSET $3
 	`, sortBy, order)
 
-	rows, err := database.DB.Query(query, "%"+search+"%", limit, offset)
+	rows, err := r.DB.QueryContext(ctx, query, "%"+search+"%", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -73,9 +73,9 @@ func ListPekerjaanRepo(search, sortBy, order string, limit, offset int) ([]model
 	return items, rows.Err()
 }
 
-func CountPekerjaanRepo(search string) (int, error) {
+func (r *PekerjaanRepository) CountPekerjaanRepo(ctx context.Context, search string) (int, error) {
 	var total int
-	err := database.DB.QueryRow(`
+	err := r.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*)
 		FROM pekerjaan_alumni
 		WHERE is_delete = FALSE
@@ -89,8 +89,8 @@ func CountPekerjaanRepo(search string) (int, error) {
 
 
 
-func (r *PekerjaanRepository) GetAllPekerjaan() ([]models.PekerjaanAlumni, error) {
-	rows, err := r.DB.Query("SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete FROM pekerjaan_alumni WHERE is_delete = FALSE ORDER BY created_at DESC")
+func (r *PekerjaanRepository) GetAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error) {
+	rows, err := r.DB.QueryContext(ctx, "SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete FROM pekerjaan_alumni WHERE is_delete = FALSE ORDER BY created_at DESC")
 	if err != nil {
 		return nil, err
 	}
@@ -107,17 +107,17 @@ func (r *PekerjaanRepository) GetAllPekerjaan() ([]models.PekerjaanAlumni, error
 	return pekerjaanList, nil
 }
 
-func (r *PekerjaanRepository) GetPekerjaanByID(id int) (*models.PekerjaanAlumni, error) {
+func (r *PekerjaanRepository) GetPekerjaanByID(ctx context.Context, id int) (*models.PekerjaanAlumni, error) {
 	var p models.PekerjaanAlumni
-	err := r.DB.QueryRow("SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at FROM pekerjaan_alumni WHERE id = $1", id).Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt)
+	err := r.DB.QueryRowContext(ctx, "SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at FROM pekerjaan_alumni WHERE id = $1", id).Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
 	return &p, nil
 }
 
-func (r *PekerjaanRepository) GetPekerjaanByAlumniID(alumniID int) ([]models.PekerjaanAlumni, error) {
-	rows, err := r.DB.Query("SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at FROM pekerjaan_alumni WHERE alumni_id = $1 ORDER BY tanggal_mulai_kerja DESC", alumniID)
+func (r *PekerjaanRepository) GetPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error) {
+	rows, err := r.DB.QueryContext(ctx, "SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at FROM pekerjaan_alumni WHERE alumni_id = $1 ORDER BY tanggal_mulai_kerja DESC", alumniID)
 	if err != nil {
 		return nil, err
 	}
@@ -134,9 +134,9 @@ func (r *PekerjaanRepository) GetPekerjaanByAlumniID(alumniID int) ([]models.Pek
 	return pekerjaanList, nil
 }
 
-func (r *PekerjaanRepository) CreatePekerjaan(p *models.PekerjaanAlumni) (int, error) {
+func (r *PekerjaanRepository) CreatePekerjaan(ctx context.Context, p *models.PekerjaanAlumni) (int, error) {
 	var id int
-	err := r.DB.QueryRow(
+	err := r.DB.QueryRowContext(ctx, 
 		`INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at) 
 		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
 		p.AlumniID, p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange, p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan, time.Now(), time.Now(),
@@ -144,8 +144,8 @@ func (r *PekerjaanRepository) CreatePekerjaan(p *models.PekerjaanAlumni) (int, e
 	return id, err
 }
 
-func (r *PekerjaanRepository) UpdatePekerjaan(id int, p *models.PekerjaanAlumni) (int64, error) {
-	result, err := r.DB.Exec(
+func (r *PekerjaanRepository) UpdatePekerjaan(ctx context.Context, id int, p *models.PekerjaanAlumni) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, 
 		`UPDATE pekerjaan_alumni SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3, lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6, tanggal_selesai_kerja = $7, status_pekerjaan = $8, deskripsi_pekerjaan = $9, updated_at = $10 
 		 WHERE id = $11`,
 		p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange, p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan, time.Now(), id,
@@ -156,7 +156,7 @@ func (r *PekerjaanRepository) UpdatePekerjaan(id int, p *models.PekerjaanAlumni)
 	return result.RowsAffected()
 }
 
-func (r *PekerjaanRepository) SoftDeletePekerjaan(id int, deletedBy int) (int64, error) {
+func (r *PekerjaanRepository) SoftDeletePekerjaan(ctx context.Context, id int, deletedBy int) (int64, error) {
 	now := time.Now()
 	query := `
         UPDATE pekerjaan_alumni
@@ -165,7 +165,7 @@ func (r *PekerjaanRepository) SoftDeletePekerjaan(id int, deletedBy int) (int64,
             deleted_by = $2
         WHERE id = $3 AND is_delete = FALSE
     `
-	result, err := r.DB.Exec(query, now, deletedBy, id)
+	result, err := r.DB.ExecContext(ctx, query, now, deletedBy, id)
 	if err != nil {
 		return 0, err
 	}
@@ -174,8 +174,8 @@ func (r *PekerjaanRepository) SoftDeletePekerjaan(id int, deletedBy int) (int64,
 
 
 // Untuk admin
-func (r *PekerjaanRepository) TrashAllPekerjaan() ([]models.PekerjaanAlumni, error) {
-    rows, err := r.DB.Query(`
+func (r *PekerjaanRepository) TrashAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error) {
+    rows, err := r.DB.QueryContext(ctx, `
         SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
                lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
                status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at,
@@ -207,8 +207,8 @@ func (r *PekerjaanRepository) TrashAllPekerjaan() ([]models.PekerjaanAlumni, err
 
 
 // Untuk user
-func (r *PekerjaanRepository) TrashPekerjaanByAlumniID(alumniID int) ([]models.PekerjaanAlumni, error) {
-	rows, err := r.DB.Query(`
+func (r *PekerjaanRepository) TrashPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error) {
+	rows, err := r.DB.QueryContext(ctx, `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range,
 		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan,
 		       created_at, updated_at, is_delete
@@ -240,9 +240,9 @@ func (r *PekerjaanRepository) TrashPekerjaanByAlumniID(alumniID int) ([]models.P
 
 
 
-func (r *PekerjaanRepository) IsPekerjaanOwnedByUser(pekerjaanID, alumniID int) (bool, error) {
+func (r *PekerjaanRepository) IsPekerjaanOwnedByUser(ctx context.Context, pekerjaanID, alumniID int) (bool, error) {
 	var count int
-	err := r.DB.QueryRow(`
+	err := r.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*) 
 		FROM pekerjaan_alumni 
 		WHERE id = $1 AND alumni_id = $2
@@ -255,8 +255,8 @@ func (r *PekerjaanRepository) IsPekerjaanOwnedByUser(pekerjaanID, alumniID int)
 	return count > 0, nil
 }
 
-func (r *PekerjaanRepository) RestorePekerjaanByID(id int) error {
-	_, err := r.DB.Exec(`
+func (r *PekerjaanRepository) RestorePekerjaanByID(ctx context.Context, id int) error {
+	_, err := r.DB.ExecContext(ctx, `
 		UPDATE pekerjaan_alumni
 		SET is_delete = FALSE, deleted_at = NULL, deleted_by = ''
 		WHERE id = $1
@@ -266,17 +266,39 @@ func (r *PekerjaanRepository) RestorePekerjaanByID(id int) error {
 
 
 
-func (r *PekerjaanRepository) HardDeletePekerjaanByID(id int) error {
-	_, err := r.DB.Exec(`
+func (r *PekerjaanRepository) HardDeletePekerjaanByID(ctx context.Context, id int) error {
+	_, err := r.DB.ExecContext(ctx, `
 		DELETE FROM pekerjaan_alumni
 		WHERE id = $1 AND is_delete = TRUE
 	`, id)
 	return err
 }
 
-func (r *PekerjaanRepository) IsTrashedPekerjaanOwnedByUser(pekerjaanID, alumniID int) (bool, error) {
+// CountTrashedPekerjaanBefore menghitung pekerjaan di trash yang dihapus sebelum waktu tertentu
+func (r *PekerjaanRepository) CountTrashedPekerjaanBefore(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM pekerjaan_alumni
+		WHERE is_delete = TRUE AND deleted_at < $1
+	`, before).Scan(&count)
+	return count, err
+}
+
+// PurgeTrashedPekerjaanBefore menghapus permanen pekerjaan di trash yang dihapus sebelum waktu tertentu
+func (r *PekerjaanRepository) PurgeTrashedPekerjaanBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		DELETE FROM pekerjaan_alumni
+		WHERE is_delete = TRUE AND deleted_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *PekerjaanRepository) IsTrashedPekerjaanOwnedByUser(ctx context.Context, pekerjaanID, alumniID int) (bool, error) {
 	var count int
-	err := r.DB.QueryRow(`
+	err := r.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*)
 		FROM pekerjaan_alumni
 		WHERE id = $1 AND alumni_id = $2 AND is_delete = TRUE
@@ -287,3 +309,20 @@ func (r *PekerjaanRepository) IsTrashedPekerjaanOwnedByUser(pekerjaanID, alumniI
 	return count > 0, nil
 }
 
+
+// GetCurrentPekerjaanByAlumniID mengambil pekerjaan yang masih berjalan (belum ada tanggal selesai)
+func (r *PekerjaanRepository) GetCurrentPekerjaanByAlumniID(ctx context.Context, alumniID int) (*models.PekerjaanAlumni, error) {
+	var p models.PekerjaanAlumni
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at
+		FROM pekerjaan_alumni
+		WHERE alumni_id = $1 AND is_delete = FALSE AND tanggal_selesai_kerja IS NULL
+		ORDER BY tanggal_mulai_kerja DESC
+		LIMIT 1
+	`, alumniID).Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
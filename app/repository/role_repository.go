@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+
+	"github.com/lib/pq"
+)
+
+type RoleRepository struct {
+	DB DBTX
+}
+
+// EnsureBuiltinRoles membuat role bawaan yang belum ada beserta grant
+// awalnya. Role yang sudah ada tidak diubah supaya kustomisasi admin tidak
+// tertimpa; permission sendiri diisi lewat migrasi
+// (0016_seed_builtin_permissions dan migrasi sesudahnya).
+func (r *RoleRepository) EnsureBuiltinRoles(ctx context.Context) error {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, role := range models.BuiltinRoles() {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO roles (name, description) VALUES ($1, $2)
+			ON CONFLICT (name) DO NOTHING
+		`, role.Name, role.Description)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO role_permissions (role_name, permission)
+			SELECT $1, name FROM permissions WHERE name = ANY($2)
+			ON CONFLICT DO NOTHING
+		`, role.Name, pq.Array(role.Permissions)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *RoleRepository) Exists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM roles WHERE name = $1)`, name).Scan(&exists)
+	return exists, err
+}
+
+func (r *RoleRepository) PermissionsForRole(ctx context.Context, name string) ([]string, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT permission FROM role_permissions
+		WHERE role_name = $1
+		ORDER BY permission
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	perms := []string{}
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		perms = append(perms, p)
+	}
+	return perms, rows.Err()
+}
+
+func (r *RoleRepository) GetAllRoles(ctx context.Context) ([]models.Role, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT name, description FROM roles ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range roles {
+		if roles[i].Permissions, err = r.PermissionsForRole(ctx, roles[i].Name); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) GetAllPermissions(ctx context.Context) ([]models.Permission, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT name, description FROM permissions ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var perms []models.Permission
+	for rows.Next() {
+		var p models.Permission
+		if err := rows.Scan(&p.Name, &p.Description); err != nil {
+			return nil, err
+		}
+		perms = append(perms, p)
+	}
+	return perms, rows.Err()
+}
+
+// HasActiveHolder melaporkan apakah ada user aktif (tidak nonaktif) yang
+// role-nya memegang perm
+func (r *RoleRepository) HasActiveHolder(ctx context.Context, perm string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM users u
+			JOIN role_permissions rp ON rp.role_name = u.role
+			WHERE rp.permission = $1 AND NOT u.disabled
+		)
+	`, perm).Scan(&exists)
+	return exists, err
+}
+
+// SaveRole membuat atau mengganti role beserta seluruh permission-nya
+func (r *RoleRepository) SaveRole(ctx context.Context, role models.Role) error {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO roles (name, description) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET description = EXCLUDED.description
+	`, role.Name, role.Description); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_name = $1`, role.Name); err != nil {
+		return err
+	}
+	for _, p := range role.Permissions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO role_permissions (role_name, permission) VALUES ($1, $2)
+		`, role.Name, p); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
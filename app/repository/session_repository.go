@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"go_clean/app/models"
+	"go_clean/utils"
+	"time"
+)
+
+type SessionRepository struct {
+	DB DBTX
+}
+
+// CreateSession membuat sesi baru (keluarga refresh token) untuk user
+func (r *SessionRepository) CreateSession(ctx context.Context, userID int) (string, error) {
+	id, err := utils.RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.DB.ExecContext(ctx, `
+		INSERT INTO auth_sessions (id, user_id, created_at)
+		VALUES ($1, $2, $3)
+	`, id, userID, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// CreateImpersonationSession membuat sesi untuk userID yang dibuka oleh admin actorID.
+// Sesi ini ikut tercabut jika sesi user dicabut (disable, ganti role, dst).
+func (r *SessionRepository) CreateImpersonationSession(ctx context.Context, userID, actorID int) (string, error) {
+	id, err := utils.RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.DB.ExecContext(ctx, `
+		INSERT INTO auth_sessions (id, user_id, actor_id, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, id, userID, actorID, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *SessionRepository) IsSessionActive(ctx context.Context, id string) (bool, error) {
+	var active bool
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM auth_sessions WHERE id = $1 AND revoked_at IS NULL
+		)
+	`, id).Scan(&active)
+	return active, err
+}
+
+func (r *SessionRepository) RevokeSession(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE auth_sessions SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`, time.Now(), id)
+	return err
+}
+
+// RevokeUserSessions mencabut semua sesi milik user, kecuali sesi exceptID (boleh kosong)
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID int, exceptID string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE auth_sessions SET revoked_at = $1
+		WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL
+	`, time.Now(), userID, exceptID)
+	return err
+}
+
+func (r *SessionRepository) CreateRefreshToken(ctx context.Context, sessionID, tokenHash string, expiresAt time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, sessionID, tokenHash, expiresAt, time.Now())
+	return err
+}
+
+func (r *SessionRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var t models.RefreshToken
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT rt.id, rt.session_id, s.user_id, rt.token_hash, rt.expires_at, rt.used_at, rt.created_at,
+		       s.revoked_at IS NOT NULL
+		FROM refresh_tokens rt
+		JOIN auth_sessions s ON s.id = rt.session_id
+		WHERE rt.token_hash = $1
+	`, tokenHash).Scan(&t.ID, &t.SessionID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.CreatedAt, &t.SessionRevoked)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RotateRefreshToken menandai token lama sudah dipakai dan menyimpan penggantinya
+// dalam satu transaksi. Mengembalikan false jika token lama ternyata sudah dipakai
+// lebih dulu (misal request paralel dengan token yang sama).
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, oldID int, sessionID, newHash string, expiresAt time.Time) (bool, error) {
+	tx, err := beginTx(ctx, r.DB)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`, now, oldID)
+	if err != nil {
+		return false, err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, sessionID, newHash, expiresAt, now)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go_clean/app/models"
+)
+
+// Interface penyimpanan per aggregate. Service bergantung pada interface ini,
+// bukan pada implementasi Postgres/Mongo, sehingga bisa diganti implementasi
+// in-memory (package repository/memory) di test.
+//
+// Kontrak error mengikuti implementasi database: data yang tidak ada
+// dikembalikan sebagai sql.ErrNoRows (Postgres) atau error yang sama dengan
+// repository Mongo-nya.
+
+// AlumniStore menyimpan data alumni
+type AlumniStore interface {
+	GetAllAlumni(ctx context.Context) ([]models.Alumni, error)
+	GetAlumniAndPekerjaan(ctx context.Context, id int) (*models.AlumniPekerjaan, error)
+	GetAlumniByID(ctx context.Context, id int) (*models.Alumni, error)
+	GetAlumniByNIM(ctx context.Context, nim string) (*models.Alumni, error)
+	GetAlumniByAngkatan(ctx context.Context, angkatan int) (*models.AlumniAngkatan, error)
+	CreateAlumni(ctx context.Context, alumni *models.Alumni) (int, error)
+	UpdateAlumni(ctx context.Context, id int, alumni *models.Alumni) (int64, error)
+	UpdateContact(ctx context.Context, id int, noTelepon, alamat *string) error
+	DeleteAlumni(ctx context.Context, id int) (int64, error)
+	ListAlumniRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.Alumni, error)
+	CountAlumniRepo(ctx context.Context, search string) (int, error)
+}
+
+// PekerjaanStore menyimpan riwayat pekerjaan alumni, termasuk trash (soft delete)
+type PekerjaanStore interface {
+	GetAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error)
+	GetPekerjaanByID(ctx context.Context, id int) (*models.PekerjaanAlumni, error)
+	GetPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error)
+	GetCurrentPekerjaanByAlumniID(ctx context.Context, alumniID int) (*models.PekerjaanAlumni, error)
+	CreatePekerjaan(ctx context.Context, p *models.PekerjaanAlumni) (int, error)
+	UpdatePekerjaan(ctx context.Context, id int, p *models.PekerjaanAlumni) (int64, error)
+	ListPekerjaanRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error)
+	CountPekerjaanRepo(ctx context.Context, search string) (int, error)
+	IsPekerjaanOwnedByUser(ctx context.Context, pekerjaanID, alumniID int) (bool, error)
+
+	SoftDeletePekerjaan(ctx context.Context, id int, deletedBy int) (int64, error)
+	TrashAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error)
+	TrashPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error)
+	IsTrashedPekerjaanOwnedByUser(ctx context.Context, pekerjaanID, alumniID int) (bool, error)
+	RestorePekerjaanByID(ctx context.Context, id int) error
+	HardDeletePekerjaanByID(ctx context.Context, id int) error
+	CountTrashedPekerjaanBefore(ctx context.Context, before time.Time) (int64, error)
+	PurgeTrashedPekerjaanBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AlumniMongoStore menyimpan dokumen alumni (koleksi Mongo). id boleh _id
+// (ObjectID hex) atau alumni_id numerik; FindByID, Update, dan Delete
+// mengembalikan mongo.ErrNoDocuments jika tidak ada dokumen yang cocok.
+type AlumniMongoStore interface {
+	Create(ctx context.Context, data *models.AlumniMongo) (*models.AlumniMongo, error)
+	FindAll(ctx context.Context) ([]models.AlumniMongo, error)
+	FindByID(ctx context.Context, id string) (*models.AlumniMongo, error)
+	Update(ctx context.Context, id string, data *models.AlumniMongo) (*models.AlumniMongo, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// PekerjaanMongoStore menyimpan dokumen pekerjaan (koleksi Mongo). FindByID,
+// Update, dan Delete mengembalikan mongo.ErrNoDocuments jika id tidak ada.
+type PekerjaanMongoStore interface {
+	Create(ctx context.Context, p *models.PekerjaanMongo) (*models.PekerjaanMongo, error)
+	FindAll(ctx context.Context) ([]models.PekerjaanMongo, error)
+	FindByID(ctx context.Context, id string) (*models.PekerjaanMongo, error)
+	FindByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanMongo, error)
+	Update(ctx context.Context, id string, p *models.PekerjaanMongo) (*models.PekerjaanMongo, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// TxRunner menjalankan beberapa panggilan repository sebagai satu unit;
+// dipenuhi *UnitOfWork
+type TxRunner interface {
+	Do(ctx context.Context, opts *sql.TxOptions, fn func(r Repositories) error) error
+}
+
+// Stores adalah bagian Repositories yang juga punya implementasi in-memory
+// (package memory)
+type Stores struct {
+	Alumni    AlumniStore
+	Pekerjaan PekerjaanStore
+}
+
+// StoreTxRunner seperti TxRunner, tetapi fn hanya menerima Stores; dipenuhi
+// *UnitOfWork dan memory.DB. Service alumni dan pekerjaan memakai ini supaya
+// bisa dijalankan tanpa Postgres, dan pemakaian repository lain di dalam
+// transaksinya gagal saat kompilasi.
+type StoreTxRunner interface {
+	DoStores(ctx context.Context, opts *sql.TxOptions, fn func(s Stores) error) error
+}
+
+var (
+	_ AlumniStore         = (*AlumniRepository)(nil)
+	_ PekerjaanStore      = (*PekerjaanRepository)(nil)
+	_ AlumniMongoStore    = (*AlumniMongoRepository)(nil)
+	_ PekerjaanMongoStore = (*PekerjaanMongoRepository)(nil)
+	_ TxRunner            = (*UnitOfWork)(nil)
+	_ StoreTxRunner       = (*UnitOfWork)(nil)
+)
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DBTX dipenuhi *sql.DB dan *sql.Tx, sehingga repository yang sama bisa
+// dipakai langsung (auto-commit) maupun di dalam UnitOfWork
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Repositories adalah semua repository Postgres di atas satu koneksi atau transaksi
+type Repositories struct {
+	Alumni    AlumniStore
+	Pekerjaan PekerjaanStore
+	Users     *UserRepository
+	Sessions  *SessionRepository
+	Resets    *PasswordResetRepository
+	Throttles *LoginThrottleRepository
+	MFA       *MFARepository
+	Roles     *RoleRepository
+	Claims    *ClaimRepository
+	APIKeys   *APIKeyRepository
+	Audit     *AuditRepository
+	OIDC      *OIDCRepository
+}
+
+func NewRepositories(db DBTX) Repositories {
+	return Repositories{
+		Alumni:    &AlumniRepository{DB: db},
+		Pekerjaan: &PekerjaanRepository{DB: db},
+		Users:     &UserRepository{DB: db},
+		Sessions:  &SessionRepository{DB: db},
+		Resets:    &PasswordResetRepository{DB: db},
+		Throttles: &LoginThrottleRepository{DB: db},
+		MFA:       &MFARepository{DB: db},
+		Roles:     &RoleRepository{DB: db},
+		Claims:    &ClaimRepository{DB: db},
+		APIKeys:   &APIKeyRepository{DB: db},
+		Audit:     &AuditRepository{DB: db},
+		OIDC:      &OIDCRepository{DB: db},
+	}
+}
+
+// UnitOfWork menjalankan beberapa panggilan repository dalam satu sql.Tx
+type UnitOfWork struct {
+	DB *sql.DB
+	// MaxRetries adalah jumlah percobaan ulang saat transaksi gagal karena
+	// serialization failure / deadlock; 0 = pakai default (3)
+	MaxRetries int
+}
+
+const defaultTxRetries = 3
+
+// Do menjalankan fn di dalam transaksi dengan opsi opts (nil = default
+// database, READ COMMITTED di Postgres). Repository yang diterima fn terikat
+// ke transaksi tersebut. fn yang mengembalikan error membatalkan transaksi.
+//
+// Jika transaksi gagal dengan serialization failure (40001) atau deadlock
+// (40P01), seluruh fn diulang dari awal dengan jeda acak; karena itu fn tidak
+// boleh punya efek samping di luar database (kirim email, dsb.).
+func (u *UnitOfWork) Do(ctx context.Context, opts *sql.TxOptions, fn func(r Repositories) error) error {
+	retries := u.MaxRetries
+	if retries <= 0 {
+		retries = defaultTxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		err := u.run(ctx, opts, fn)
+		if err == nil || !isRetryable(err) || attempt >= retries {
+			return err
+		}
+		// backoff eksponensial dengan jitter: 10ms, 20ms, 40ms, ... (+ acak)
+		wait := time.Duration(10<<attempt)*time.Millisecond + rand.N(10*time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// DoStores menjalankan Do dengan fn yang hanya melihat Stores
+func (u *UnitOfWork) DoStores(ctx context.Context, opts *sql.TxOptions, fn func(s Stores) error) error {
+	return u.Do(ctx, opts, func(r Repositories) error {
+		return fn(Stores{Alumni: r.Alumni, Pekerjaan: r.Pekerjaan})
+	})
+}
+
+func (u *UnitOfWork) run(ctx context.Context, opts *sql.TxOptions, fn func(r Repositories) error) error {
+	tx, err := u.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(NewRepositories(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// isRetryable mengenali error Postgres yang aman diulang dengan transaksi baru
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return true
+	}
+	return false
+}
+
+// txHandle adalah transaksi milik satu method repository
+type txHandle interface {
+	DBTX
+	Commit() error
+	Rollback() error
+}
+
+// joinedTx membungkus transaksi milik UnitOfWork: commit/rollback diserahkan
+// ke pemiliknya
+type joinedTx struct{ *sql.Tx }
+
+func (joinedTx) Commit() error   { return nil }
+func (joinedTx) Rollback() error { return nil }
+
+// beginTx memulai transaksi untuk method repository yang butuh beberapa
+// statement. Jika repository sudah berjalan di dalam UnitOfWork, transaksi
+// tersebut yang dipakai.
+func beginTx(ctx context.Context, db DBTX) (txHandle, error) {
+	switch db := db.(type) {
+	case *sql.Tx:
+		return joinedTx{db}, nil
+	case *sql.DB:
+		return db.BeginTx(ctx, nil)
+	default:
+		return nil, fmt.Errorf("repository: %T tidak mendukung transaksi", db)
+	}
+}
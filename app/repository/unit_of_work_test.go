@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// stubDriver adalah driver database/sql minimal: commit gagal dengan
+// commitErrs berurutan, lalu berhasil. Query tidak didukung.
+type stubDriver struct {
+	mu         sync.Mutex
+	commitErrs []error
+	begins     int
+	commits    int
+	isolation  []driver.IsolationLevel
+}
+
+func (d *stubDriver) Connect(context.Context) (driver.Conn, error) { return stubConn{d}, nil }
+func (d *stubDriver) Driver() driver.Driver                        { return nil }
+
+type stubConn struct{ d *stubDriver }
+
+func (stubConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("stub: query tidak didukung")
+}
+func (stubConn) Close() error { return nil }
+func (c stubConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c stubConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.begins++
+	c.d.isolation = append(c.d.isolation, opts.Isolation)
+	return stubTx{c.d}, nil
+}
+
+type stubTx struct{ d *stubDriver }
+
+func (t stubTx) Commit() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.commits++
+	if len(t.d.commitErrs) == 0 {
+		return nil
+	}
+	err := t.d.commitErrs[0]
+	t.d.commitErrs = t.d.commitErrs[1:]
+	return err
+}
+
+func (stubTx) Rollback() error { return nil }
+
+func newStubUoW(commitErrs ...error) (*UnitOfWork, *stubDriver) {
+	d := &stubDriver{commitErrs: commitErrs}
+	return &UnitOfWork{DB: sql.OpenDB(d)}, d
+}
+
+var (
+	errSerialization = &pq.Error{Code: "40001", Message: "could not serialize access"}
+	errDeadlock      = &pq.Error{Code: "40P01", Message: "deadlock detected"}
+)
+
+func TestUnitOfWorkRetriesSerializationFailure(t *testing.T) {
+	uow, d := newStubUoW(errSerialization)
+	calls := 0
+	err := uow.Do(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable}, func(r Repositories) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do = %v, want nil setelah retry", err)
+	}
+	if calls != 2 || d.begins != 2 || d.commits != 2 {
+		t.Errorf("fn %d kali, begin %d, commit %d; want masing-masing 2", calls, d.begins, d.commits)
+	}
+	for i, iso := range d.isolation {
+		if sql.IsolationLevel(iso) != sql.LevelSerializable {
+			t.Errorf("transaksi ke-%d berjalan dengan isolation %v, want SERIALIZABLE", i, sql.IsolationLevel(iso))
+		}
+	}
+}
+
+func TestUnitOfWorkRetriesDeadlockFromFn(t *testing.T) {
+	uow, _ := newStubUoW()
+	calls := 0
+	err := uow.Do(context.Background(), nil, func(r Repositories) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("update users: %w", errDeadlock)
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("Do = %v dengan fn %d kali, want nil dan 2 kali", err, calls)
+	}
+}
+
+func TestUnitOfWorkGivesUp(t *testing.T) {
+	t.Run("error lain tidak diulang", func(t *testing.T) {
+		uow, _ := newStubUoW()
+		want := &pq.Error{Code: "23505"}
+		calls := 0
+		err := uow.Do(context.Background(), nil, func(r Repositories) error {
+			calls++
+			return want
+		})
+		if err != want || calls != 1 {
+			t.Fatalf("Do = %v dengan fn %d kali, want %v dan 1 kali", err, calls, want)
+		}
+	})
+	t.Run("batas retry", func(t *testing.T) {
+		uow, d := newStubUoW(errSerialization, errSerialization, errSerialization)
+		uow.MaxRetries = 2
+		err := uow.Do(context.Background(), nil, func(r Repositories) error { return nil })
+		if err != errSerialization || d.begins != 3 {
+			t.Fatalf("Do = %v dengan %d transaksi, want 40001 setelah 3 transaksi", err, d.begins)
+		}
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errSerialization, true},
+		{errDeadlock, true},
+		{fmt.Errorf("commit: %w", errSerialization), true},
+		{&pq.Error{Code: "23505"}, false},
+		{&pq.Error{Code: "57014"}, false},
+		{errors.New("40001"), false},
+		{sql.ErrNoRows, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.want {
+			t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -1,35 +1,52 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"strings"
 	"fmt"
-	"log"
 	"go_clean/app/models"
+	"time"
 )
 
 type UserRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
-func (r *UserRepository) GetByUsernameOrEmail(identifier string) (*models.User, string, error) {
-	u := models.User{}
+// kolom yang dibaca setiap query user, urutannya harus sama dengan scanUser
+const userColumns = `id, username, email, email_verified_at IS NOT NULL, role, alumni_id, disabled, password_reset_required, COALESCE(locale, ''), created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner, extra ...interface{}) (*models.User, error) {
+	var u models.User
+	dest := append([]interface{}{
+		&u.ID, &u.Username, &u.Email, &u.EmailVerified, &u.Role, &u.AlumniID, &u.Disabled, &u.PasswordResetRequired, &u.Locale, &u.CreatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *UserRepository) GetByUsernameOrEmail(ctx context.Context, identifier string) (*models.User, string, error) {
 	var hash string
-	err := r.DB.QueryRow(`
-		SELECT id, username, email, password_hash, role
+	u, err := scanUser(r.DB.QueryRowContext(ctx, `
+		SELECT `+userColumns+`, password_hash
 		FROM users
 		WHERE username = $1 OR email = $1
-	`, identifier).Scan(&u.ID, &u.Username, &u.Email, &hash, &u.Role)
+	`, identifier), &hash)
 	if err != nil {
 		return nil, "", err
 	}
-	return &u, hash, nil
+	return u, hash, nil
 }
 
-func (r *UserRepository) ExistsByUsernameOrEmail(username, email string) (bool, error) {
+func (r *UserRepository) ExistsByUsernameOrEmail(ctx context.Context, username, email string) (bool, error) {
 	var exists bool
-	err := r.DB.QueryRow(`
+	err := r.DB.QueryRowContext(ctx, `
 		SELECT EXISTS (
 			SELECT 1 FROM users WHERE username = $1 OR email = $2
 		)
@@ -37,74 +54,202 @@ func (r *UserRepository) ExistsByUsernameOrEmail(username, email string) (bool,
 	return exists, err
 }
 
-func (r *UserRepository) Create(username, email, passwordHash, role string) (*models.User, error) {
+func (r *UserRepository) Create(ctx context.Context, username, email, passwordHash, role string) (*models.User, error) {
+	// validasi role dilakukan service lewat RoleRepository; FK users.role → roles.name jadi pengaman terakhir
 	role = strings.ToLower(role)
-	if role != "admin" && role != "user" {
-		return nil, errors.New("role tidak valid")
-	}
-	var u models.User
-	err := r.DB.QueryRow(`
+	return scanUser(r.DB.QueryRowContext(ctx, `
 		INSERT INTO users (username, email, password_hash, role)
 		VALUES ($1, $2, $3, $4)
-		RETURNING id, username, email, role
-	`, username, email, passwordHash, role).Scan(&u.ID, &u.Username, &u.Email, &u.Role)
-	if err != nil {
-		return nil, err
+		RETURNING `+userColumns,
+		username, email, passwordHash, role))
+}
+
+// UsersSortable: kolom users yang boleh dipakai sortBy
+func UsersSortable() map[string]bool {
+	return map[string]bool{"id": true, "username": true, "email": true, "role": true, "created_at": true}
+}
+
+func sanitizeUserSort(s string) string {
+	if UsersSortable()[s] {
+		return s
 	}
-	return &u, nil
+	return "id"
+}
+
+func sanitizeOrderUser(o string) string {
+	if o == "desc" || o == "DESC" {
+		return "DESC"
+	}
+	return "ASC"
 }
-func (r *UserRepository) GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.User, error) {
+
+func (r *UserRepository) GetUsersRepo(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.User, error) {
 	query := fmt.Sprintf(`
-		SELECT id, username, email, created_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE username ILIKE $1 OR email ILIKE $1
-		ORDER BY %s %s
+		ORDER BY %s %s, id ASC
 		LIMIT $2 OFFSET $3
-	`, sortBy, order)
+	`, sanitizeUserSort(sortBy), sanitizeOrderUser(order))
 
-	rows, err := r.DB.Query(query, "%"+search+"%", limit, offset)
+	rows, err := r.DB.QueryContext(ctx, query, "%"+search+"%", limit, offset)
 	if err != nil {
-		log.Println("Query error:", err)
 		return nil, err
 	}
 	defer rows.Close()
 
-	var users []models.User
+	users := []models.User{}
 	for rows.Next() {
-		var u models.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.Email); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			return nil, err
 		}
-		users = append(users, u)
+		users = append(users, *u)
 	}
-
-	return users, nil
+	return users, rows.Err()
 }
 
-func (r *UserRepository) CountUsersRepo(search string) (int, error) {
+func (r *UserRepository) CountUsersRepo(ctx context.Context, search string) (int, error) {
 	var total int
 	countQuery := `SELECT COUNT(*) FROM users WHERE username ILIKE $1 OR email ILIKE $1`
-	err := r.DB.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+	err := r.DB.QueryRowContext(ctx, countQuery, "%"+search+"%").Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
 		return 0, err
 	}
 	return total, nil
 }
 
-func (r *UserRepository) GetUserByID(id int) (*models.User, error) {
-    var u models.User
-    err := r.DB.QueryRow(`
-        SELECT id, username, email, role, alumni_id
-        FROM users
-        WHERE id = $1
-    `, id).Scan(
-        &u.ID, &u.Username, &u.Email, &u.Role, &u.AlumniID,
-    )
-    if err != nil {
-        return nil, err
-    }
-    return &u, nil
+func (r *UserRepository) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	return scanUser(r.DB.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id = $1
+	`, id))
 }
 
+// UpdatePassword juga menghapus flag password_reset_required
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int, passwordHash string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET password_hash = $1, password_reset_required = FALSE
+		WHERE id = $2
+	`, passwordHash, id)
+	return err
+}
 
+func (r *UserRepository) UpdateRole(ctx context.Context, id int, role string) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET role = $1 WHERE id = $2`, strings.ToLower(role), id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
 
+func (r *UserRepository) SetDisabled(ctx context.Context, id int, disabled bool) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET disabled = $1 WHERE id = $2`, disabled, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *UserRepository) SetPasswordResetRequired(ctx context.Context, id int, required bool) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET password_reset_required = $1 WHERE id = $2`, required, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SetAlumniID menautkan (atau melepas jika nil) akun ke data alumni
+func (r *UserRepository) SetAlumniID(ctx context.Context, id int, alumniID *int) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET alumni_id = $1 WHERE id = $2`, alumniID, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetUserByAlumniID mencari akun yang sudah tertaut ke alumni tertentu
+func (r *UserRepository) GetUserByAlumniID(ctx context.Context, alumniID int) (*models.User, error) {
+	return scanUser(r.DB.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE alumni_id = $1
+	`, alumniID))
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, id int) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *UserRepository) GetPasswordHash(ctx context.Context, id int) (string, error) {
+	var hash string
+	err := r.DB.QueryRowContext(ctx, `SELECT password_hash FROM users WHERE id = $1`, id).Scan(&hash)
+	return hash, err
+}
+
+// ExistsByUsernameOrEmailExcept seperti ExistsByUsernameOrEmail tapi mengabaikan user id sendiri
+func (r *UserRepository) ExistsByUsernameOrEmailExcept(ctx context.Context, username, email string, id int) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM users WHERE (username = $1 OR email = $2) AND id <> $3
+		)
+	`, username, email, id).Scan(&exists)
+	return exists, err
+}
+
+// UpdateProfile mengubah username/email; ganti email membatalkan status
+// verifikasi dan throttle kirim ulang, supaya link ke alamat baru langsung terkirim
+func (r *UserRepository) UpdateProfile(ctx context.Context, id int, username, email string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE users
+		SET username = $1, email = $2,
+		    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
+		    email_verification_sent_at = CASE WHEN email = $2 THEN email_verification_sent_at END
+		WHERE id = $3
+	`, username, email, id)
+	return err
+}
+
+// UpdateLocale menyimpan bahasa pilihan user; string kosong menghapus preferensi
+func (r *UserRepository) UpdateLocale(ctx context.Context, id int, locale string) error {
+	_, err := r.DB.ExecContext(ctx, `UPDATE users SET locale = NULLIF($1, '') WHERE id = $2`, locale, id)
+	return err
+}
+
+// MarkEmailVerified menandai email terverifikasi, hanya jika email user masih
+// sama dengan yang ada di link (link lama tidak berlaku setelah ganti email)
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id int, email string) (int64, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET email_verified_at = $1
+		WHERE id = $2 AND email = $3 AND email_verified_at IS NULL
+	`, time.Now(), id, email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// TouchVerificationSent mencatat waktu kirim link verifikasi. Mengembalikan
+// false jika link terakhir dikirim kurang dari interval yang lalu (throttle).
+func (r *UserRepository) TouchVerificationSent(ctx context.Context, id int, interval time.Duration) (bool, error) {
+	now := time.Now()
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET email_verification_sent_at = $1
+		WHERE id = $2 AND (email_verification_sent_at IS NULL OR email_verification_sent_at < $3)
+	`, now, id, now.Add(-interval))
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return scanUser(r.DB.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE lower(email) = lower($1)`, email))
+}
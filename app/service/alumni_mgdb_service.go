@@ -8,10 +8,10 @@ import (
 )
 
 type AlumniMongoService struct {
-	repo *repository.AlumniMongoRepository
+	repo repository.AlumniMongoStore
 }
 
-func NewAlumniMongoService(repo *repository.AlumniMongoRepository) *AlumniMongoService {
+func NewAlumniMongoService(repo repository.AlumniMongoStore) *AlumniMongoService {
 	return &AlumniMongoService{repo: repo}
 }
 
@@ -1,248 +1,142 @@
 package service
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"go_clean/app/apperror"
 	"go_clean/app/models"
 	"go_clean/app/repository"
-	"strconv"
-
-	"github.com/gofiber/fiber/v2"
 )
 
 type AlumniService struct {
-	Repo *repository.AlumniRepository
+	Repo repository.AlumniStore
+	// Tx dipakai untuk tulis + baca ulang dalam satu transaksi
+	Tx repository.StoreTxRunner
 }
 
-func (s *AlumniService) GetAllAlumni(c *fiber.Ctx) error {
-	alumni, err := s.Repo.GetAllAlumni()
+func (s *AlumniService) GetAllAlumni(ctx context.Context) ([]models.Alumni, error) {
+	alumni, err := s.Repo.GetAllAlumni(ctx)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-		})
-	}
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data alumni berhasil diambil",
-		"data":    alumni,
-	})
+		return nil, apperror.Wrap(err, "alumni_fetch_failed")
+	}
+	return alumni, nil
 }
 
-func GetAlumniList(c *fiber.Ctx) error {
+// GetAlumniList mengembalikan satu halaman alumni dengan search & sort
+func (s *AlumniService) GetAlumniList(ctx context.Context, params ListParams) (models.UserResponse[models.Alumni], error) {
 	sortable := make(map[string]bool)
 	for _, v := range repository.AlumniSortable() {
 		sortable[v] = true
 	}
-	params := getListParams(c, sortable) // lihat fungsi accessor di bawah
-	items, err := repository.ListAlumniRepo(params.Search, params.SortBy, params.Order, params.Limit, params.Offset)
+	params = params.normalize(sortable)
+	items, err := s.Repo.ListAlumniRepo(ctx, params.Search, params.SortBy, params.Order, params.Limit, params.Offset)
 	if err != nil {
-		// DEBUG: log detail error
-		fmt.Printf("ListAlumniRepo error: %v\n", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error": "failed to fetch alumni",
-		})
+		return models.UserResponse[models.Alumni]{}, apperror.Wrap(err, "alumni_fetch_failed")
 	}
 
-	total, err := repository.CountAlumniRepo(params.Search)
+	total, err := s.Repo.CountAlumniRepo(ctx, params.Search)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "failed to count alumni"})
+		return models.UserResponse[models.Alumni]{}, apperror.Wrap(err, "alumni_count_failed")
 	}
-
-	resp := models.UserResponse[models.Alumni]{
-		Data: items,
-		Meta: models.MetaInfo{
-			Page: params.Page, Limit: params.Limit, Total: total,
-			Pages:  (total + params.Limit - 1) / params.Limit,
-			SortBy: params.SortBy, Order: params.Order, Search: params.Search,
-		},
-	}
-	return c.JSON(resp)
+	return page(items, total, params), nil
 }
 
-func (s *AlumniService) GetAlumniByID(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID tidak valid",
-		})
+func (s *AlumniService) GetAlumniByID(ctx context.Context, id int) (*models.Alumni, error) {
+	alumni, err := s.Repo.GetAlumniByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("alumni_not_found")
 	}
-
-	alumni, err := s.Repo.GetAlumniByID(id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Alumni tidak ditemukan",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-		})
-	}
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data alumni berhasil diambil",
-		"data":    alumni,
-	})
+		return nil, apperror.Wrap(err, "alumni_fetch_failed")
+	}
+	return alumni, nil
 }
 
-func (s *AlumniService) GetAlumniByAngkatan(c *fiber.Ctx) error {
-	angkatan, err := strconv.Atoi(c.Params("angkatan"))
+func (s *AlumniService) GetAlumniByAngkatan(ctx context.Context, angkatan int) (*models.AlumniAngkatan, error) {
+	result, err := s.Repo.GetAlumniByAngkatan(ctx, angkatan)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Angkatan tidak valid",
-		})
+		return nil, apperror.Wrap(err, "alumni_fetch_failed")
 	}
+	return result, nil
+}
 
-	result, err := s.Repo.GetAlumniByAngkatan(angkatan)
+func (s *AlumniService) GetAlumniAndPekerjaan(ctx context.Context, id int) (*models.AlumniPekerjaan, error) {
+	result, err := s.Repo.GetAlumniAndPekerjaan(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-		})
+		return nil, apperror.Wrap(err, "alumni_fetch_failed")
 	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data alumni berhasil diambil",
-		"data":    result,
-	})
+	return result, nil
 }
 
-func (s *AlumniService) GetAlumniAndPekerjaan(c *fiber.Ctx) error {
-	idStr := c.Params("nim") // sebenarnya ini ID
-	if idStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID tidak valid",
-		})
+func (s *AlumniService) CreateAlumni(ctx context.Context, req models.AlumniRequest) (*models.Alumni, error) {
+	if err := validateAlumni(req); err != nil {
+		return nil, err
 	}
 
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID harus berupa angka",
-		})
+	alumni := alumniFromRequest(req)
+	var newAlumni *models.Alumni
+	err := s.Tx.DoStores(ctx, nil, func(tx repository.Stores) error {
+		newID, err := tx.Alumni.CreateAlumni(ctx, &alumni)
+		if err != nil {
+			return err
+		}
+		newAlumni, err = tx.Alumni.GetAlumniByID(ctx, newID)
+		return err
+	})
+	if repository.IsUniqueViolation(err) {
+		return nil, apperror.Conflict("alumni_duplicate")
 	}
-
-	result, err := s.Repo.GetAlumniAndPekerjaan(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data alumni dan pekerjaan: " + err.Error(),
-		})
+		return nil, apperror.Wrap(err, "alumni_create_failed")
 	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data alumni dan pekerjaan berhasil diambil",
-		"data":    result,
-	})
+	return newAlumni, nil
 }
 
-
-
-func (s *AlumniService) CreateAlumni(c *fiber.Ctx) error {
-	var alumni models.Alumni
-	if err := c.BodyParser(&alumni); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Request body tidak valid",
-		})
+func (s *AlumniService) UpdateAlumni(ctx context.Context, id int, req models.AlumniRequest) (*models.Alumni, error) {
+	alumni := alumniFromRequest(req)
+	var updatedAlumni *models.Alumni
+	err := s.Tx.DoStores(ctx, nil, func(tx repository.Stores) error {
+		rowsAffected, err := tx.Alumni.UpdateAlumni(ctx, id, &alumni)
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+		updatedAlumni, err = tx.Alumni.GetAlumniByID(ctx, id)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("alumni_not_found")
 	}
-
-	if alumni.NIM == "" || alumni.Nama == "" || alumni.Jurusan == "" || alumni.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field NIM, Nama, Jurusan, dan Email wajib diisi",
-		})
+	if repository.IsUniqueViolation(err) {
+		return nil, apperror.Conflict("alumni_duplicate")
 	}
-
-	newID, err := s.Repo.CreateAlumni(&alumni)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal menambah alumni: " + err.Error(),
-		})
+		return nil, apperror.Wrap(err, "alumni_update_failed")
 	}
-
-	newAlumni, _ := s.Repo.GetAlumniByID(newID)
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "Alumni berhasil ditambahkan",
-		"data":    newAlumni,
-	})
+	return updatedAlumni, nil
 }
 
-func (s *AlumniService) UpdateAlumni(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID tidak valid",
-		})
-	}
-
-	var alumni models.Alumni
-	if err := c.BodyParser(&alumni); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Request body tidak valid",
-		})
-	}
-
-	rowsAffected, err := s.Repo.UpdateAlumni(id, &alumni)
+func (s *AlumniService) DeleteAlumni(ctx context.Context, id int) error {
+	rowsAffected, err := s.Repo.DeleteAlumni(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengupdate alumni: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni_delete_failed")
 	}
 	if rowsAffected == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Alumni tidak ditemukan untuk diupdate",
-		})
+		return apperror.NotFound("alumni_not_found")
 	}
-
-	updatedAlumni, _ := s.Repo.GetAlumniByID(id)
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Alumni berhasil diupdate",
-		"data":    updatedAlumni,
-	})
+	return nil
 }
 
-func (s *AlumniService) DeleteAlumni(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID tidak valid",
-		})
-	}
+// validateAlumni memeriksa field wajib dan melaporkan semua yang kosong
+func validateAlumni(req models.AlumniRequest) error {
+	return required("nim", req.NIM, "nama", req.Nama, "jurusan", req.Jurusan, "email", req.Email)
+}
 
-	rowsAffected, err := s.Repo.DeleteAlumni(id)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal menghapus alumni: " + err.Error(),
-		})
-	}
-	if rowsAffected == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Alumni tidak ditemukan untuk dihapus",
-		})
+func alumniFromRequest(req models.AlumniRequest) models.Alumni {
+	return models.Alumni{
+		NIM: req.NIM, Nama: req.Nama, Jurusan: req.Jurusan, Angkatan: req.Angkatan,
+		TahunLulus: req.TahunLulus, Email: req.Email, NoTelepon: req.NoTelepon, Alamat: req.Alamat,
 	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Alumni berhasil dihapus",
-	})
 }
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/helper"
+	"go_clean/utils"
+)
+
+// APIKeyService mengelola API key untuk integrasi antar sistem (butuh users:manage)
+type APIKeyService struct {
+	Keys  *repository.APIKeyRepository
+	Users *repository.UserRepository
+	Roles *repository.RoleRepository
+	Auth  config.AuthConfig
+}
+
+func (s *APIKeyService) GetAPIKeys(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	keys, err := s.Keys.GetAll(ctx)
+	if err != nil {
+		return apperror.Wrap(err, "api_key_fetch_failed")
+	}
+	return c.JSON(fiber.Map{"data": keys})
+}
+
+// CreateAPIKey menerbitkan key untuk akun layanan. Scope dibatasi pada
+// permission role akun tersebut supaya key tidak bisa melebihi pemiliknya, dan
+// tidak boleh memuat permission privileged karena API key tidak melewati 2FA.
+func (s *APIKeyService) CreateAPIKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || req.UserID == 0 {
+		return apperror.Validation(apperror.Required("name"), apperror.Required("user_id"))
+	}
+
+	cfg := s.Auth
+	ttl := cfg.APIKeyDefaultTTL
+	if req.ExpiresInDays > 0 {
+		ttl = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	if ttl > cfg.APIKeyMaxTTL {
+		return apperror.Validation(apperror.Field("expires_in_days", "too_large"))
+	}
+
+	owner, err := s.Users.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.Invalid("user_not_found")
+		}
+		return apperror.Internal(err)
+	}
+	if owner.Disabled {
+		return apperror.Invalid("api_key_owner_disabled")
+	}
+	allowed, err := s.Roles.PermissionsForRole(ctx, owner.Role)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	for _, scope := range req.Scopes {
+		if models.PrivilegedPermission(scope) {
+			return apperror.Invalid("scope_privileged", scope)
+		}
+		if !containsString(allowed, scope) {
+			return apperror.Invalid("scope_not_allowed", scope)
+		}
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return apperror.Wrap(err, "api_key_generate_failed")
+	}
+	raw := models.APIKeyPrefix + secret
+	actorID, _ := c.Locals("user_id").(int)
+	key := models.APIKey{
+		Name:      req.Name,
+		Prefix:    raw[:len(models.APIKeyPrefix)+8],
+		KeyHash:   utils.HashToken(raw),
+		UserID:    owner.ID,
+		Username:  owner.Username,
+		Role:      owner.Role,
+		Scopes:    req.Scopes,
+		ExpiresAt: time.Now().Add(ttl),
+		CreatedBy: &actorID,
+	}
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+	if err := s.Keys.Create(ctx, &key); err != nil {
+		return apperror.Wrap(err, "api_key_save_failed")
+	}
+	return c.Status(201).JSON(models.CreateAPIKeyResponse{Key: raw, Data: key})
+}
+
+func (s *APIKeyService) RevokeAPIKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Invalid("invalid_api_key_id")
+	}
+	revoked, err := s.Keys.Revoke(ctx, id)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !revoked {
+		return apperror.NotFound("api_key_not_found")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "api_key_revoked")})
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/helper"
+	"go_clean/utils"
+)
+
+type AuthService struct {
+	Users    *repository.UserRepository
+	Sessions *repository.SessionRepository
+	Tokens   *TokenIssuer
+	Lockout  *LockoutService
+	MFA      *MFAService
+}
+
+// accountBlocked mengembalikan error jika akun tidak boleh mendapat token
+func accountBlocked(u models.User) error {
+	if u.Disabled {
+		return apperror.Forbidden("account_disabled")
+	}
+	if u.PasswordResetRequired {
+		return apperror.Forbidden("password_reset_required")
+	}
+	return nil
+}
+
+func (s *AuthService) Login(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.LoginRequest
+	if err := c.BodyParser(&req); err != nil || req.Username == "" || req.Password == "" {
+		return apperror.Validation(apperror.Required("username"), apperror.Required("password"))
+	}
+
+	ip := c.IP()
+	wait, err := s.Lockout.lockedFor(ctx, models.LockScopeIP, ip)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if wait > 0 {
+		return tooManyAttempts(wait)
+	}
+
+	u, hash, err := s.Users.GetByUsernameOrEmail(ctx, req.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if err := s.Lockout.registerFailure(ctx, models.LockScopeIP, ip, nil, ip); err != nil {
+				return apperror.Internal(err)
+			}
+			return apperror.Unauthorized("invalid_credentials")
+		}
+		return apperror.Internal(err)
+	}
+
+	accountKey := strconv.Itoa(u.ID)
+	wait, err = s.Lockout.lockedFor(ctx, models.LockScopeAccount, accountKey)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if wait > 0 {
+		return tooManyAttempts(wait)
+	}
+
+	if !utils.CheckPassword(req.Password, hash) {
+		if err := s.Lockout.registerFailure(ctx, models.LockScopeAccount, accountKey, &u.ID, ip); err != nil {
+			return apperror.Internal(err)
+		}
+		if err := s.Lockout.registerFailure(ctx, models.LockScopeIP, ip, nil, ip); err != nil {
+			return apperror.Internal(err)
+		}
+		return apperror.Unauthorized("invalid_credentials")
+	}
+
+	// hanya scope akun yang di-reset: jika IP ikut di-reset, penyerang yang
+	// punya satu akun valid bisa login di sela tebakan dan lockout IP tidak
+	// pernah tercapai. Counter IP turun sendiri lewat auth.failure_window.
+	if err := s.Lockout.reset(ctx, models.LockScopeAccount, accountKey); err != nil {
+		return apperror.Internal(err)
+	}
+
+	if err := accountBlocked(*u); err != nil {
+		return err
+	}
+
+	st, err := s.MFA.MFA.GetTOTP(ctx, u.ID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	required, err := s.MFA.mfaRequired(ctx, *u, st)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if required {
+		return s.MFA.challenge(c, *u, st)
+	}
+
+	resp, err := s.Tokens.Issue(ctx, *u)
+	if err != nil {
+		return apperror.Wrap(err, "token_issue_failed")
+	}
+	return c.JSON(resp)
+}
+
+// RefreshToken menukar refresh token dengan pasangan token baru (rotasi).
+// Refresh token yang sudah pernah dirotasi lalu dipakai lagi dianggap bocor,
+// sehingga seluruh sesinya dicabut.
+func (s *AuthService) RefreshToken(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.RefreshRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.RefreshToken) == "" {
+		return apperror.Validation(apperror.Required("refresh_token"))
+	}
+
+	rt, err := s.Sessions.GetRefreshTokenByHash(ctx, utils.HashToken(strings.TrimSpace(req.RefreshToken)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.Unauthorized("refresh_token_invalid")
+		}
+		return apperror.Internal(err)
+	}
+	if rt.SessionRevoked {
+		return apperror.Unauthorized("session_revoked")
+	}
+	if rt.UsedAt != nil {
+		return s.revokeReusedSession(c, rt)
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		return apperror.Unauthorized("refresh_token_expired")
+	}
+
+	u, err := s.Users.GetUserByID(ctx, rt.UserID)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	if blocked := accountBlocked(*u); blocked != nil {
+		if err := s.Sessions.RevokeSession(ctx, rt.SessionID); err != nil {
+			return apperror.Internal(err)
+		}
+		return blocked
+	}
+
+	raw, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return apperror.Wrap(err, "token_issue_failed")
+	}
+	rotated, err := s.Sessions.RotateRefreshToken(ctx, rt.ID, rt.SessionID, hash, time.Now().Add(s.Tokens.JWT.Config().RefreshTTL))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !rotated {
+		// kalah balapan dengan request lain yang memakai token yang sama
+		return s.revokeReusedSession(c, rt)
+	}
+
+	resp, err := s.Tokens.respond(ctx, *u, rt.SessionID, raw)
+	if err != nil {
+		return apperror.Wrap(err, "token_issue_failed")
+	}
+	return c.JSON(resp)
+}
+
+func (s *AuthService) revokeReusedSession(c *fiber.Ctx, rt *models.RefreshToken) error {
+	ctx := c.UserContext()
+	log.Printf("refresh token reuse terdeteksi: user=%d session=%s", rt.UserID, rt.SessionID)
+	if err := s.Sessions.RevokeSession(ctx, rt.SessionID); err != nil {
+		return apperror.Internal(err)
+	}
+	return apperror.Unauthorized("refresh_token_reused")
+}
+
+// Logout mencabut sesi dari access token yang sedang dipakai
+func (s *AuthService) Logout(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	sessionID, _ := c.Locals("session_id").(string)
+	if sessionID == "" {
+		return apperror.Invalid("session_required")
+	}
+	if err := s.Sessions.RevokeSession(ctx, sessionID); err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "logged_out")})
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/helper"
+	"go_clean/mailer"
+	"go_clean/utils"
+)
+
+const claimMaxAttempts = 5
+
+// ClaimService menautkan akun user ke data alumni lewat verifikasi NIM:
+// kode sekali pakai dikirim ke email yang tercatat di baris alumni.
+// Klaim yang bentrok (alumni sudah diklaim / email tidak bisa diakses)
+// masuk antrian review admin.
+type ClaimService struct {
+	Claims *repository.ClaimRepository
+	Users  *repository.UserRepository
+	Alumni repository.AlumniStore
+	Mailer mailer.Mailer
+	Auth   config.AuthConfig
+	Tx     repository.TxRunner
+}
+
+// maskEmail: "budi.santoso@gmail.com" → "b***o@gmail.com"
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return "***"
+	}
+	local := email[:at]
+	if len(local) <= 2 {
+		return local[:1] + "***" + email[at:]
+	}
+	return local[:1] + "***" + local[len(local)-1:] + email[at:]
+}
+
+func optionalNote(note string) *string {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil
+	}
+	return &note
+}
+
+// POST /api/claims — mulai klaim dengan NIM
+func (s *ClaimService) CreateClaim(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.CreateClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	req.NIM = strings.TrimSpace(req.NIM)
+	if req.NIM == "" {
+		return apperror.Validation(apperror.Required("nim"))
+	}
+
+	userID, _ := c.Locals("user_id").(int)
+	u, err := s.Users.GetUserByID(ctx, userID)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	if u.AlumniID != nil {
+		return apperror.Conflict("account_already_linked")
+	}
+
+	alumni, err := s.Alumni.GetAlumniByNIM(ctx, req.NIM)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("nim_not_found")
+		}
+		return apperror.Internal(err)
+	}
+
+	// klaim pending untuk alumni yang sama dipakai ulang: selama kodenya masih
+	// berlaku tidak dikirim kode baru, dan jatah percobaannya tidak di-reset
+	pending, err := s.Claims.GetPending(ctx, u.ID, alumni.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return apperror.Internal(err)
+	}
+	if pending != nil {
+		if pending.CodeExpiresAt != nil && time.Now().Before(*pending.CodeExpiresAt) {
+			return apperror.Conflict("claim_already_pending")
+		}
+		if pending.Attempts >= claimMaxAttempts {
+			return apperror.TooManyRequests("claim_too_many_attempts", 0)
+		}
+	} else {
+		n, err := s.Claims.CountSince(ctx, u.ID, time.Now().Add(-s.Auth.ClaimWindow))
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if n >= s.Auth.ClaimMaxPerWindow {
+			return apperror.TooManyRequests("claim_rate_limited", s.Auth.ClaimWindow)
+		}
+	}
+
+	claim := pending
+	if claim == nil {
+		claim = &models.AlumniClaim{
+			UserID:   u.ID,
+			AlumniID: alumni.ID,
+			NIM:      alumni.NIM,
+			Note:     optionalNote(req.Note),
+		}
+	}
+
+	owner, err := s.Users.GetUserByAlumniID(ctx, alumni.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return apperror.Internal(err)
+	}
+	if owner != nil || !isEmail(alumni.Email) {
+		// sudah diklaim akun lain atau tidak ada email untuk verifikasi → review admin
+		if pending != nil {
+			err = s.Claims.UpdateStatus(ctx, claim.ID, models.ClaimDisputed, optionalNote(req.Note), nil)
+		} else {
+			claim.Status = models.ClaimDisputed
+			err = s.Claims.Create(ctx, claim)
+		}
+		if err != nil {
+			return apperror.Wrap(err, "claim_save_failed")
+		}
+		claim.Status = models.ClaimDisputed
+		return c.Status(202).JSON(fiber.Map{
+			"message": helper.Message(c, "claim_review_required"),
+			"data":    claim,
+		})
+	}
+
+	code, err := utils.RandomDigits(6)
+	if err != nil {
+		return apperror.Wrap(err, "claim_code_failed")
+	}
+	ttl := s.Auth.ClaimCodeTTL
+	hash := utils.HashToken(code)
+	expires := time.Now().Add(ttl)
+	claim.Status = models.ClaimPending
+	claim.CodeHash = &hash
+	claim.CodeExpiresAt = &expires
+	if pending != nil {
+		err = s.Claims.RenewCode(ctx, claim.ID, hash, expires)
+	} else {
+		err = s.Claims.Create(ctx, claim)
+	}
+	if repository.IsUniqueViolation(err) {
+		// request paralel sudah membuat klaim pending untuk alumni ini
+		return apperror.Conflict("claim_already_pending")
+	}
+	if err != nil {
+		return apperror.Wrap(err, "claim_save_failed")
+	}
+
+	err = s.Mailer.Send(mailer.Message{
+		To:      alumni.Email,
+		Subject: "Kode verifikasi klaim akun alumni",
+		Body: fmt.Sprintf("Halo %s,\n\nAkun \"%s\" ingin ditautkan ke data alumni NIM %s.\n"+
+			"Kode verifikasi: %s\n\nKode berlaku %d menit. Abaikan email ini jika bukan kamu.\n",
+			alumni.Nama, u.Username, alumni.NIM, code, int(ttl.Minutes())),
+	})
+	if err != nil {
+		return apperror.Wrap(fmt.Errorf("klaim %d: %w", claim.ID, err), "claim_code_email_failed")
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": helper.Message(c, "claim_code_sent", maskEmail(alumni.Email)),
+		"data":    claim,
+	})
+}
+
+// ownClaim mengambil klaim milik user yang sedang login
+func (s *ClaimService) ownClaim(c *fiber.Ctx) (*models.AlumniClaim, error) {
+	ctx := c.UserContext()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, apperror.Invalid("invalid_claim_id")
+	}
+	claim, err := s.Claims.GetByID(ctx, id)
+	userID, _ := c.Locals("user_id").(int)
+	if err == sql.ErrNoRows || (err == nil && claim.UserID != userID) {
+		return nil, apperror.NotFound("claim_not_found")
+	}
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return claim, nil
+}
+
+// POST /api/claims/:id/verify — tukar kode dari email dengan tautan ke alumni
+func (s *ClaimService) VerifyClaim(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claim, err := s.ownClaim(c)
+	if claim == nil {
+		return err
+	}
+	var req models.VerifyClaimRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Code) == "" {
+		return apperror.Validation(apperror.Required("code"))
+	}
+
+	if claim.Status != models.ClaimPending || claim.CodeHash == nil {
+		return apperror.Conflict("claim_not_pending")
+	}
+	if claim.CodeExpiresAt == nil || time.Now().After(*claim.CodeExpiresAt) {
+		return apperror.Invalid("claim_code_expired")
+	}
+
+	// jatah percobaan dipakai sebelum kode dibandingkan, atomik di database,
+	// sehingga request paralel tidak bisa melewati claimMaxAttempts
+	_, err = s.Claims.UseAttempt(ctx, claim.ID, claimMaxAttempts)
+	if err == sql.ErrNoRows {
+		return apperror.TooManyRequests("claim_too_many_attempts", 0)
+	}
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	got := utils.HashToken(strings.TrimSpace(req.Code))
+	if subtle.ConstantTimeCompare([]byte(got), []byte(*claim.CodeHash)) != 1 {
+		return apperror.Invalid("claim_code_invalid")
+	}
+
+	// SERIALIZABLE: dua klaim atas alumni yang sama (atau kode yang sama
+	// dipakai paralel) tidak boleh sama-sama lolos pengecekan di bawah
+	escalated := false
+	err = s.Tx.Do(ctx, serializable, func(tx repository.Repositories) error {
+		escalated = false
+		cur, err := tx.Claims.GetByID(ctx, claim.ID)
+		if err != nil {
+			return err
+		}
+		if cur.Status != models.ClaimPending {
+			return sql.ErrNoRows
+		}
+		// alumni bisa saja diklaim akun lain selama kode belum dipakai
+		owner, err := tx.Users.GetUserByAlumniID(ctx, claim.AlumniID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if owner != nil && owner.ID != claim.UserID {
+			escalated = true
+			return tx.Claims.UpdateStatus(ctx, claim.ID, models.ClaimDisputed, nil, nil)
+		}
+		return tx.Claims.LinkUser(ctx, claim.ID, claim.UserID, claim.AlumniID, models.ClaimVerified, nil, false)
+	})
+	if err == sql.ErrNoRows {
+		return apperror.Conflict("claim_not_pending")
+	}
+	if err != nil {
+		return apperror.Wrap(err, "account_link_failed")
+	}
+	if escalated {
+		return c.Status(202).JSON(fiber.Map{"message": helper.Message(c, "claim_escalated")})
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "account_linked"), "alumni_id": claim.AlumniID})
+}
+
+// POST /api/claims/:id/dispute — minta review admin (misal email alumni sudah tidak aktif)
+func (s *ClaimService) DisputeClaim(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claim, err := s.ownClaim(c)
+	if claim == nil {
+		return err
+	}
+	var req models.ClaimNoteRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Note) == "" {
+		return apperror.Validation(apperror.Required("note"))
+	}
+	if claim.Status != models.ClaimPending {
+		return apperror.Conflict("claim_not_disputable")
+	}
+	if err := s.Claims.UpdateStatus(ctx, claim.ID, models.ClaimDisputed, optionalNote(req.Note), nil); err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "claim_disputed")})
+}
+
+// GET /api/claims — riwayat klaim milik user
+func (s *ClaimService) GetMyClaims(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, _ := c.Locals("user_id").(int)
+	claims, err := s.Claims.GetByUserID(ctx, userID)
+	if err != nil {
+		return apperror.Wrap(err, "claim_fetch_failed")
+	}
+	return c.JSON(fiber.Map{"data": claims})
+}
+
+// ADMIN: GET /api/admin/claims?status=disputed — antrian review
+func (s *ClaimService) GetClaimQueue(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	status := c.Query("status", models.ClaimDisputed)
+	claims, err := s.Claims.GetByStatus(ctx, status)
+	if err != nil {
+		return apperror.Wrap(err, "claim_fetch_failed")
+	}
+	return c.JSON(fiber.Map{"data": claims})
+}
+
+func (s *ClaimService) reviewedClaim(c *fiber.Ctx) (*models.AlumniClaim, *models.ClaimNoteRequest, error) {
+	ctx := c.UserContext()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, nil, apperror.Invalid("invalid_claim_id")
+	}
+	var req models.ClaimNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, nil, apperror.InvalidPayload()
+	}
+	claim, err := s.Claims.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, apperror.NotFound("claim_not_found")
+		}
+		return nil, nil, apperror.Internal(err)
+	}
+	if claim.Status != models.ClaimDisputed && claim.Status != models.ClaimPending {
+		return nil, nil, apperror.Conflict("claim_already_decided")
+	}
+	return claim, &req, nil
+}
+
+// ADMIN: POST /api/admin/claims/:id/approve — tautkan akun, tautan lama ke alumni itu dilepas
+func (s *ClaimService) ApproveClaim(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claim, req, err := s.reviewedClaim(c)
+	if claim == nil {
+		return err
+	}
+	adminID, _ := c.Locals("user_id").(int)
+	err = s.Tx.Do(ctx, serializable, func(tx repository.Repositories) error {
+		if note := optionalNote(req.Note); note != nil {
+			if err := tx.Claims.UpdateStatus(ctx, claim.ID, claim.Status, note, nil); err != nil {
+				return err
+			}
+		}
+		return tx.Claims.LinkUser(ctx, claim.ID, claim.UserID, claim.AlumniID, models.ClaimApproved, &adminID, true)
+	})
+	if err != nil {
+		return apperror.Wrap(err, "account_link_failed")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "claim_approved")})
+}
+
+// ADMIN: POST /api/admin/claims/:id/reject
+func (s *ClaimService) RejectClaim(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claim, req, err := s.reviewedClaim(c)
+	if claim == nil {
+		return err
+	}
+	adminID, _ := c.Locals("user_id").(int)
+	if err := s.Claims.UpdateStatus(ctx, claim.ID, models.ClaimRejected, optionalNote(req.Note), &adminID); err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "claim_rejected")})
+}
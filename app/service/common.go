@@ -1,12 +1,21 @@
 package service
 
 import (
-	"strconv"
+	"database/sql"
 	"strings"
 
-	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
 )
 
+// serializable dipakai transaksi yang membaca lalu menulis berdasarkan hasil
+// bacaan (tautan klaim, grant role); UnitOfWork mengulang transaksi yang gagal
+// karena serialization failure
+var serializable = &sql.TxOptions{Isolation: sql.LevelSerializable}
+
+// ListParams adalah parameter list dengan search, sort, dan pagination.
+// Page/Limit/SortBy/Order dinormalisasi service, jadi transport cukup
+// meneruskan nilai mentah dari request.
 type ListParams struct {
 	Page   int
 	Limit  int
@@ -16,28 +25,52 @@ type ListParams struct {
 	Offset int
 }
 
-func getListParams(c *fiber.Ctx, whitelist map[string]bool) ListParams {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	if page < 1 { page = 1 }
-
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	if limit < 1 { limit = 10 }
-	if limit > 100 { limit = 100 }
-
-	sortBy := c.Query("sortBy", "id")
-	if !whitelist[sortBy] {
-		sortBy = "id"
+// normalize menerapkan default dan batas: page >= 1, limit 1..100 (default
+// 10), sortBy harus ada di whitelist (default "id"), order asc/desc
+func (p ListParams) normalize(whitelist map[string]bool) ListParams {
+	if p.Page < 1 {
+		p.Page = 1
 	}
-
-	order := strings.ToLower(c.Query("order", "asc"))
-	if order != "desc" {
-		order = "asc"
+	if p.Limit < 1 {
+		p.Limit = 10
+	}
+	if p.Limit > 100 {
+		p.Limit = 100
+	}
+	if !whitelist[p.SortBy] {
+		p.SortBy = "id"
 	}
+	p.Order = strings.ToLower(p.Order)
+	if p.Order != "desc" {
+		p.Order = "asc"
+	}
+	p.Offset = (p.Page - 1) * p.Limit
+	return p
+}
 
-	search := c.Query("search", "")
+// page membungkus satu halaman hasil list beserta meta pagination
+func page[T any](items []T, total int, p ListParams) models.UserResponse[T] {
+	return models.UserResponse[T]{
+		Data: items,
+		Meta: models.MetaInfo{
+			Page: p.Page, Limit: p.Limit, Total: total,
+			Pages:  (total + p.Limit - 1) / p.Limit,
+			SortBy: p.SortBy, Order: p.Order, Search: p.Search,
+		},
+	}
+}
 
-	return ListParams{
-		Page: page, Limit: limit, SortBy: sortBy, Order: order, Search: search,
-		Offset: (page - 1) * limit,
+// required mengembalikan error validasi berisi setiap field yang kosong;
+// kv berisi pasangan nama field dan nilainya
+func required(kv ...string) error {
+	var fields []apperror.FieldError
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] == "" {
+			fields = append(fields, apperror.Required(kv[i]))
+		}
+	}
+	if fields != nil {
+		return apperror.Validation(fields...)
 	}
+	return nil
 }
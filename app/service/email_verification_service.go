@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/helper"
+	"go_clean/mailer"
+	"go_clean/utils"
+)
+
+// EmailVerificationService mengirim dan memproses link verifikasi email.
+// Link berisi token bertanda tangan (lihat utils.JWT.GenerateEmailVerificationToken),
+// jadi tidak perlu tabel token terpisah.
+type EmailVerificationService struct {
+	Users  *repository.UserRepository
+	Mailer mailer.Mailer
+	Auth   config.AuthConfig
+	JWT    *utils.JWT
+	// URL frontend untuk link di email
+	BaseURL string
+}
+
+// errVerifyThrottled: link verifikasi baru saja dikirim
+var errVerifyThrottled = errors.New("link verifikasi baru saja dikirim, coba lagi nanti")
+
+// sendLink mengirim link verifikasi ke email user, dibatasi satu kali per interval
+func (s *EmailVerificationService) sendLink(ctx context.Context, u models.User) error {
+	cfg := s.Auth
+	allowed, err := s.Users.TouchVerificationSent(ctx, u.ID, cfg.EmailVerifyResendInterval)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errVerifyThrottled
+	}
+
+	tok, err := s.JWT.GenerateEmailVerificationToken(u, cfg.EmailVerifyTTL)
+	if err != nil {
+		return err
+	}
+	link := s.BaseURL + "/verify-email?token=" + url.QueryEscape(tok)
+	return s.Mailer.Send(mailer.Message{
+		To:      u.Email,
+		Subject: "Verifikasi email Alumni API",
+		Body: fmt.Sprintf("Halo %s,\n\nKlik link berikut untuk memverifikasi email kamu:\n%s\n\n"+
+			"Link ini berlaku %d jam. Sebelum email terverifikasi, akun kamu hanya bisa melihat data.\n",
+			u.Username, link, int(cfg.EmailVerifyTTL.Hours())),
+	})
+}
+
+// sendAfterSignup dipakai setelah akun dibuat/email diganti; kegagalan kirim
+// tidak menggagalkan request karena user masih bisa minta kirim ulang
+func (s *EmailVerificationService) sendAfterSignup(ctx context.Context, u models.User) {
+	if err := s.sendLink(ctx, u); err != nil {
+		log.Printf("gagal kirim email verifikasi ke user %d: %v", u.ID, err)
+	}
+}
+
+// POST /api/email/verification — kirim ulang link verifikasi untuk user login
+func (s *EmailVerificationService) Resend(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, _ := c.Locals("user_id").(int)
+	u, err := s.Users.GetUserByID(ctx, userID)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	if u.EmailVerified {
+		return apperror.Invalid("email_already_verified")
+	}
+	if err := s.sendLink(ctx, *u); err != nil {
+		if err == errVerifyThrottled {
+			return apperror.TooManyRequests("verification_throttled", s.Auth.EmailVerifyResendInterval)
+		}
+		return apperror.Wrap(fmt.Errorf("user %d: %w", u.ID, err), "verification_email_failed")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "verification_sent", u.Email)})
+}
+
+// PUBLIC: POST /api/email/verify — konfirmasi memakai token dari link
+func (s *EmailVerificationService) Verify(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Token) == "" {
+		return apperror.Validation(apperror.Required("token"))
+	}
+	claims, err := s.JWT.ValidateToken(strings.TrimSpace(req.Token), models.TokenPurposeVerifyEmail)
+	if err != nil || claims.Email == "" {
+		return apperror.Invalid("verification_link_invalid")
+	}
+	rows, err := s.Users.MarkEmailVerified(ctx, claims.UserID, claims.Email)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if rows == 0 {
+		return apperror.Invalid("verification_link_used")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "email_verified")})
+}
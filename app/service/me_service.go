@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/i18n"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/helper"
+	"go_clean/utils"
+)
+
+// MeService melayani /api/me: profil milik user yang sedang login
+type MeService struct {
+	Users     *repository.UserRepository
+	Alumni    repository.AlumniStore
+	Pekerjaan repository.PekerjaanStore
+	Sessions  *repository.SessionRepository
+	// ganti email mengirim link verifikasi baru
+	Verification *EmailVerificationService
+	// password baru dicek dengan policy yang sama dengan reset password
+	Passwords *PasswordService
+	Tx        repository.TxRunner
+}
+
+func (s *MeService) loadMe(ctx context.Context, userID int) (*models.MeResponse, error) {
+	u, err := s.Users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	me := &models.MeResponse{User: *u}
+	if u.AlumniID == nil {
+		return me, nil
+	}
+
+	alumni, err := s.Alumni.GetAlumniByID(ctx, *u.AlumniID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	me.Alumni = alumni
+
+	pekerjaan, err := s.Pekerjaan.GetCurrentPekerjaanByAlumniID(ctx, *u.AlumniID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	me.Pekerjaan = pekerjaan
+	return me, nil
+}
+
+// GET /api/me
+func (s *MeService) GetMe(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, _ := c.Locals("user_id").(int)
+	me, err := s.loadMe(ctx, userID)
+	if err != nil {
+		return apperror.Wrap(err, "profile_fetch_failed")
+	}
+	return c.JSON(me)
+}
+
+// PUT /api/me (butuh email terverifikasi)
+func (s *MeService) UpdateMe(c *fiber.Ctx) error {
+	var req models.UpdateMeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	return s.update(c, req)
+}
+
+// PUT /api/me/email — boleh sebelum email terverifikasi, untuk membetulkan
+// email yang salah ketik; field profil lain tidak diterima di sini
+func (s *MeService) UpdateEmail(c *fiber.Ctx) error {
+	var req models.UpdateEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		return apperror.Validation(apperror.Required("email"))
+	}
+	return s.update(c, models.UpdateMeRequest{Email: &req.Email})
+}
+
+// update memvalidasi seluruh req dulu, lalu menyimpan profil, kontak, dan
+// locale dalam satu transaksi supaya tidak ada perubahan yang tersimpan separuh
+func (s *MeService) update(c *fiber.Ctx, req models.UpdateMeRequest) error {
+	ctx := c.UserContext()
+	userID, _ := c.Locals("user_id").(int)
+	me, err := s.loadMe(ctx, userID)
+	if err != nil {
+		return apperror.Wrap(err, "profile_fetch_failed")
+	}
+
+	username, email := me.User.Username, me.User.Email
+	if req.Username != nil {
+		username = strings.TrimSpace(*req.Username)
+	}
+	if req.Email != nil {
+		email = strings.TrimSpace(*req.Email)
+	}
+	if username == "" {
+		return apperror.Validation(apperror.Required("username"))
+	}
+	if !isEmail(email) {
+		return invalidEmail()
+	}
+	locale, err := parseLocale(req.Locale)
+	if err != nil {
+		return err
+	}
+
+	profileChanged := username != me.User.Username || email != me.User.Email
+	if profileChanged {
+		taken, err := s.Users.ExistsByUsernameOrEmailExcept(ctx, username, email, userID)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if taken {
+			return apperror.Conflict("user_exists")
+		}
+	}
+
+	contactChanged := req.NoTelepon != nil || req.Alamat != nil
+	var noTelepon, alamat *string
+	if contactChanged {
+		if me.Alumni == nil {
+			return apperror.Invalid("alumni_not_linked")
+		}
+		noTelepon, alamat = me.Alumni.NoTelepon, me.Alumni.Alamat
+		if req.NoTelepon != nil {
+			noTelepon = req.NoTelepon
+		}
+		if req.Alamat != nil {
+			alamat = req.Alamat
+		}
+	}
+
+	err = s.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		if profileChanged {
+			if err := tx.Users.UpdateProfile(ctx, userID, username, email); err != nil {
+				return err
+			}
+		}
+		if contactChanged {
+			if err := tx.Alumni.UpdateContact(ctx, me.Alumni.ID, noTelepon, alamat); err != nil {
+				return err
+			}
+		}
+		if locale != nil {
+			return tx.Users.UpdateLocale(ctx, userID, *locale)
+		}
+		return nil
+	})
+	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return apperror.Conflict("user_exists")
+		}
+		return apperror.Wrap(err, "profile_save_failed")
+	}
+
+	if email != me.User.Email {
+		// email baru harus diverifikasi ulang; UpdateProfile sudah mereset
+		// throttle sehingga link ke alamat baru langsung terkirim
+		u := me.User
+		u.Username, u.Email = username, email
+		s.Verification.sendAfterSignup(ctx, u)
+	}
+
+	if locale != nil {
+		// token lama masih membawa locale lama sampai di-refresh; respons ini
+		// sudah memakai pilihan baru
+		if *locale == "" {
+			c.Locals("locale", nil)
+		} else {
+			c.Locals("locale", i18n.Locale(*locale))
+		}
+	}
+
+	return s.GetMe(c)
+}
+
+// parseLocale memvalidasi field locale pada UpdateMeRequest. nil = tidak
+// diubah, "" = hapus preferensi.
+func parseLocale(raw *string) (*string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	locale := strings.TrimSpace(*raw)
+	if locale == "" {
+		return &locale, nil
+	}
+	l, ok := i18n.Parse(locale)
+	if !ok {
+		supported := make([]string, 0, len(i18n.Supported()))
+		for _, s := range i18n.Supported() {
+			supported = append(supported, string(s))
+		}
+		return nil, apperror.Validation(apperror.Field("locale", "unsupported_locale", strings.Join(supported, ", ")))
+	}
+	locale = string(l)
+	return &locale, nil
+}
+
+// PUT /api/me/password — wajib password lama; sesi lain ikut dicabut
+func (s *MeService) ChangePassword(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil || req.CurrentPassword == "" || req.NewPassword == "" {
+		return apperror.Validation(apperror.Required("current_password"), apperror.Required("new_password"))
+	}
+
+	userID, _ := c.Locals("user_id").(int)
+	hash, err := s.Users.GetPasswordHash(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !utils.CheckPassword(req.CurrentPassword, hash) {
+		return apperror.Invalid("current_password_wrong")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return apperror.Invalid("password_unchanged")
+	}
+	if err := s.Passwords.checkPolicy(req.NewPassword); err != nil {
+		return err
+	}
+
+	newHash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return apperror.Wrap(err, "password_hash_failed")
+	}
+	sessionID, _ := c.Locals("session_id").(string)
+	err = s.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		if err := tx.Users.UpdatePassword(ctx, userID, newHash); err != nil {
+			return err
+		}
+		return tx.Sessions.RevokeUserSessions(ctx, userID, sessionID)
+	})
+	if err != nil {
+		return apperror.Wrap(err, "password_save_failed")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "password_changed")})
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/helper"
+	"go_clean/utils"
+)
+
+const recoveryCodeCount = 10
+
+// MFAService menangani TOTP 2FA: enrollment, verifikasi login dua langkah,
+// dan recovery code. 2FA wajib untuk user yang role-nya memegang permission
+// pengelola akun (lihat models.IsPrivileged) dan opsional untuk user lain.
+type MFAService struct {
+	Users   *repository.UserRepository
+	MFA     *repository.MFARepository
+	Roles   *repository.RoleRepository
+	Tokens  *TokenIssuer
+	Lockout *LockoutService
+	Auth    config.AuthConfig
+}
+
+// mfaRequired melaporkan apakah login u harus lewat langkah 2FA
+func (s *MFAService) mfaRequired(ctx context.Context, u models.User, st *models.TOTPState) (bool, error) {
+	if st.Enabled {
+		return true, nil
+	}
+	return s.privileged(ctx, u)
+}
+
+// privileged melaporkan apakah role u memegang permission yang mewajibkan 2FA
+func (s *MFAService) privileged(ctx context.Context, u models.User) (bool, error) {
+	perms, err := s.Roles.PermissionsForRole(ctx, u.Role)
+	if err != nil {
+		return false, err
+	}
+	return models.IsPrivileged(perms), nil
+}
+
+// challenge membalas Login dengan challenge token, bukan JWT penuh
+func (s *MFAService) challenge(c *fiber.Ctx, u models.User, st *models.TOTPState) error {
+	tok, err := s.Tokens.JWT.GenerateChallengeToken(u, s.Auth.MFAChallengeTTL)
+	if err != nil {
+		return apperror.Wrap(err, "token_issue_failed")
+	}
+	return c.JSON(models.MFAChallengeResponse{
+		MFARequired:        true,
+		EnrollmentRequired: !st.Enabled,
+		ChallengeToken:     tok,
+		ExpiresIn:          int(s.Auth.MFAChallengeTTL.Seconds()),
+	})
+}
+
+func (s *MFAService) userFromChallenge(ctx context.Context, token string) (*models.User, error) {
+	claims, err := s.Tokens.JWT.ValidateToken(strings.TrimSpace(token), models.TokenPurposeMFA)
+	if err != nil {
+		return nil, err
+	}
+	return s.Users.GetUserByID(ctx, claims.UserID)
+}
+
+func (s *MFAService) newSetup(ctx context.Context, u models.User) (*models.TOTPSetupResponse, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.MFA.SetTOTPSecret(ctx, u.ID, secret); err != nil {
+		return nil, err
+	}
+	return &models.TOTPSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.Auth.MFAIssuer, u.Email, secret),
+	}, nil
+}
+
+// checkCode memvalidasi kode TOTP sekaligus mencegah kode yang sama dipakai ulang
+func (s *MFAService) checkCode(ctx context.Context, userID int, st *models.TOTPState, code string) (bool, error) {
+	if st.Secret == nil {
+		return false, nil
+	}
+	step, ok := utils.ValidateTOTP(*st.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return s.MFA.UseTOTPStep(ctx, userID, step)
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+func (s *MFAService) newRecoveryCodes(ctx context.Context, userID int) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.RandomToken(5)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, utils.HashToken(raw))
+	}
+	if err := s.MFA.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// PUBLIC (challenge token): buat secret TOTP untuk admin yang belum enroll
+func (s *MFAService) LoginSetup(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.MFAChallengeRequest
+	if err := c.BodyParser(&req); err != nil || req.ChallengeToken == "" {
+		return apperror.Validation(apperror.Required("challenge_token"))
+	}
+	u, err := s.userFromChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return apperror.Unauthorized("challenge_token_invalid")
+	}
+	st, err := s.MFA.GetTOTP(ctx, u.ID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if st.Enabled {
+		return apperror.Conflict("mfa_already_enabled")
+	}
+
+	setup, err := s.newSetup(ctx, *u)
+	if err != nil {
+		return apperror.Wrap(err, "mfa_secret_failed")
+	}
+	return c.JSON(setup)
+}
+
+// PUBLIC (challenge token): langkah kedua login, tukar challenge + kode dengan JWT penuh.
+// Jika 2FA belum aktif (enrollment admin), kode pertama yang valid sekaligus mengaktifkannya.
+func (s *MFAService) LoginVerify(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.MFAVerifyRequest
+	if err := c.BodyParser(&req); err != nil || req.ChallengeToken == "" {
+		return apperror.Validation(apperror.Required("challenge_token"))
+	}
+	if req.Code == "" && req.RecoveryCode == "" {
+		return apperror.Validation(apperror.Required("code"))
+	}
+	u, err := s.userFromChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return apperror.Unauthorized("challenge_token_invalid")
+	}
+
+	ip := c.IP()
+	accountKey := strconv.Itoa(u.ID)
+	wait, err := s.Lockout.lockedFor(ctx, models.LockScopeAccount, accountKey)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if wait > 0 {
+		return tooManyAttempts(wait)
+	}
+
+	st, err := s.MFA.GetTOTP(ctx, u.ID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if st.Secret == nil {
+		return apperror.Invalid("mfa_not_setup")
+	}
+
+	var ok bool
+	if req.RecoveryCode != "" && st.Enabled {
+		ok, err = s.MFA.ConsumeRecoveryCode(ctx, u.ID, utils.HashToken(normalizeRecoveryCode(req.RecoveryCode)))
+	} else {
+		ok, err = s.checkCode(ctx, u.ID, st, req.Code)
+	}
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		if err := s.Lockout.registerFailure(ctx, models.LockScopeAccount, accountKey, &u.ID, ip); err != nil {
+			return apperror.Internal(err)
+		}
+		return apperror.Unauthorized("mfa_code_invalid")
+	}
+	if err := s.Lockout.reset(ctx, models.LockScopeAccount, accountKey); err != nil {
+		return apperror.Internal(err)
+	}
+
+	var codes []string
+	if !st.Enabled {
+		if err := s.MFA.EnableTOTP(ctx, u.ID); err != nil {
+			return apperror.Wrap(err, "mfa_enable_failed")
+		}
+		if codes, err = s.newRecoveryCodes(ctx, u.ID); err != nil {
+			return apperror.Wrap(err, "mfa_recovery_codes_failed")
+		}
+	}
+
+	resp, err := s.Tokens.Issue(ctx, *u)
+	if err != nil {
+		return apperror.Wrap(err, "token_issue_failed")
+	}
+	resp.RecoveryCodes = codes
+	return c.JSON(resp)
+}
+
+// Setup memulai enrollment 2FA untuk user yang sedang login
+func (s *MFAService) Setup(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	u, st, err := s.currentUser(c)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	if st.Enabled {
+		return apperror.Conflict("mfa_already_enabled")
+	}
+
+	setup, err := s.newSetup(ctx, *u)
+	if err != nil {
+		return apperror.Wrap(err, "mfa_secret_failed")
+	}
+	return c.JSON(setup)
+}
+
+// Confirm mengaktifkan 2FA setelah user memasukkan kode dari aplikasi authenticator
+func (s *MFAService) Confirm(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.TOTPCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return apperror.Validation(apperror.Required("code"))
+	}
+	u, st, err := s.currentUser(c)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	if st.Enabled {
+		return apperror.Conflict("mfa_already_enabled")
+	}
+	if st.Secret == nil {
+		return apperror.Invalid("mfa_not_setup")
+	}
+
+	ok, err := s.checkCode(ctx, u.ID, st, req.Code)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		return apperror.Invalid("mfa_code_invalid")
+	}
+	if err := s.MFA.EnableTOTP(ctx, u.ID); err != nil {
+		return apperror.Wrap(err, "mfa_enable_failed")
+	}
+	codes, err := s.newRecoveryCodes(ctx, u.ID)
+	if err != nil {
+		return apperror.Wrap(err, "mfa_recovery_codes_failed")
+	}
+	return c.JSON(fiber.Map{
+		"message":        helper.Message(c, "mfa_enabled"),
+		"recovery_codes": codes,
+	})
+}
+
+// Disable mematikan 2FA (tidak boleh bila 2FA wajib untuk role user)
+func (s *MFAService) Disable(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.TOTPCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return apperror.Validation(apperror.Required("code"))
+	}
+	u, st, err := s.currentUser(c)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	required, err := s.privileged(ctx, *u)
+	if err != nil {
+		return apperror.Wrap(err, "permission_check_failed")
+	}
+	if required {
+		return apperror.Forbidden("mfa_required")
+	}
+	if !st.Enabled {
+		return apperror.Invalid("mfa_not_enabled")
+	}
+
+	ok, err := s.checkCode(ctx, u.ID, st, req.Code)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		return apperror.Invalid("mfa_code_invalid")
+	}
+	if err := s.MFA.DisableTOTP(ctx, u.ID); err != nil {
+		return apperror.Wrap(err, "mfa_disable_failed")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "mfa_disabled")})
+}
+
+// RegenerateRecoveryCodes mengganti semua recovery code (yang lama tidak berlaku lagi)
+func (s *MFAService) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.TOTPCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return apperror.Validation(apperror.Required("code"))
+	}
+	u, st, err := s.currentUser(c)
+	if err != nil {
+		return apperror.Wrap(err, "user_fetch_failed")
+	}
+	if !st.Enabled {
+		return apperror.Invalid("mfa_not_enabled")
+	}
+
+	ok, err := s.checkCode(ctx, u.ID, st, req.Code)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		return apperror.Invalid("mfa_code_invalid")
+	}
+	codes, err := s.newRecoveryCodes(ctx, u.ID)
+	if err != nil {
+		return apperror.Wrap(err, "mfa_recovery_codes_failed")
+	}
+	return c.JSON(fiber.Map{"recovery_codes": codes})
+}
+
+func (s *MFAService) currentUser(c *fiber.Ctx) (*models.User, *models.TOTPState, error) {
+	ctx := c.UserContext()
+	userID, _ := c.Locals("user_id").(int)
+	u, err := s.Users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := s.MFA.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, st, nil
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/helper"
+	"go_clean/oidc"
+	"go_clean/utils"
+)
+
+// OIDCService menangani login SSO kampus (authorization code + PKCE).
+// Hasil akhirnya tetap JWT milik API ini, sama seperti login password.
+type OIDCService struct {
+	Client *oidc.Client
+	Repo   *repository.OIDCRepository
+	Users  *repository.UserRepository
+	Alumni repository.AlumniStore
+	Roles  *repository.RoleRepository
+	Tokens *TokenIssuer
+	MFA    *MFAService
+	Tx     repository.TxRunner
+}
+
+// errOIDCNoAccount: identitas SSO valid tapi tidak bisa dipetakan ke akun mana pun
+var errOIDCNoAccount = errors.New("akun SSO belum terhubung ke akun mana pun")
+
+// start menyiapkan state + PKCE dan mengembalikan URL login provider.
+// linkUserID nil untuk login, terisi untuk alur penautan akun.
+func (s *OIDCService) start(c *fiber.Ctx, linkUserID *int) (string, error) {
+	ctx := c.UserContext()
+	state, err := utils.RandomToken(32)
+	if err != nil {
+		return "", apperror.Wrap(err, "oidc_state_failed")
+	}
+	nonce, err := utils.RandomToken(16)
+	if err != nil {
+		return "", apperror.Wrap(err, "oidc_nonce_failed")
+	}
+	verifier, err := utils.RandomToken(32)
+	if err != nil {
+		return "", apperror.Wrap(err, "oidc_pkce_failed")
+	}
+
+	redirect, err := s.Client.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", apperror.Upstream(err, "oidc_provider_unreachable")
+	}
+	err = s.Repo.CreateState(ctx, models.OIDCLoginState{
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(s.Client.Config.StateTTL),
+		LinkUserID:   linkUserID,
+	})
+	if err != nil {
+		return "", apperror.Internal(err)
+	}
+	return redirect, nil
+}
+
+// PUBLIC: redirect ke halaman login provider
+func (s *OIDCService) Login(c *fiber.Ctx) error {
+	if !s.Client.Config.Enabled {
+		return apperror.NotFound("oidc_disabled")
+	}
+	redirect, err := s.start(c, nil)
+	if err != nil {
+		return err
+	}
+	return c.Redirect(redirect, fiber.StatusFound)
+}
+
+// GET /api/oidc/link — mulai penautan identitas SSO ke akun yang sedang
+// login. Dipanggil lewat fetch (butuh Bearer token), jadi URL provider
+// dikembalikan sebagai JSON, bukan redirect.
+func (s *OIDCService) StartLink(c *fiber.Ctx) error {
+	if !s.Client.Config.Enabled {
+		return apperror.NotFound("oidc_disabled")
+	}
+	userID, _ := c.Locals("user_id").(int)
+	redirect, err := s.start(c, &userID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"authorization_url": redirect})
+}
+
+// pendingLink menyimpan hasil callback alur penautan. Identitas belum
+// ditautkan: callback datang dari browser tanpa token, jadi pemilik akun
+// harus mengonfirmasi lewat ConfirmLink. Tanpa langkah ini penyerang bisa
+// memulai penautan untuk akunnya sendiri lalu memancing korban menyelesaikan
+// login di provider.
+func (s *OIDCService) pendingLink(c *fiber.Ctx, userID int, claims oidc.Claims) error {
+	ctx := c.UserContext()
+	cfg := s.Client.Config
+	owner, err := s.Repo.GetIdentityUserID(ctx, cfg.Issuer, claims.Subject())
+	if err == nil && owner != userID {
+		return apperror.Conflict("oidc_identity_taken")
+	}
+	if err != nil && err != sql.ErrNoRows {
+		return apperror.Internal(err)
+	}
+
+	code, err := utils.RandomToken(32)
+	if err != nil {
+		return apperror.Wrap(err, "oidc_link_failed")
+	}
+	err = s.Repo.CreatePendingLink(ctx, models.OIDCPendingLink{
+		CodeHash:  utils.HashToken(code),
+		UserID:    userID,
+		Issuer:    cfg.Issuer,
+		Subject:   claims.Subject(),
+		ExpiresAt: time.Now().Add(cfg.StateTTL),
+	})
+	if err != nil {
+		return apperror.Wrap(err, "oidc_link_failed")
+	}
+	return c.JSON(fiber.Map{
+		"message":    helper.Message(c, "oidc_link_pending"),
+		"link_code":  code,
+		"expires_in": int(cfg.StateTTL.Seconds()),
+	})
+}
+
+// POST /api/oidc/link/confirm — menautkan identitas SSO dari pendingLink;
+// hanya berhasil untuk akun yang memulai penautan
+func (s *OIDCService) ConfirmLink(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.OIDCLinkConfirmRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	if err := required("link_code", strings.TrimSpace(req.LinkCode)); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int)
+	l, err := s.Repo.ConsumePendingLink(ctx, utils.HashToken(strings.TrimSpace(req.LinkCode)), userID)
+	if err == sql.ErrNoRows {
+		return apperror.Invalid("oidc_link_code_invalid")
+	}
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if err := s.Repo.LinkIdentity(ctx, l.Issuer, l.Subject, userID); err != nil {
+		return apperror.Wrap(err, "oidc_link_failed")
+	}
+	// LinkIdentity tidak menimpa tautan yang sudah ada; pastikan milik user ini
+	owner, err := s.Repo.GetIdentityUserID(ctx, l.Issuer, l.Subject)
+	if err != nil {
+		return apperror.Wrap(err, "oidc_link_failed")
+	}
+	if owner != userID {
+		return apperror.Conflict("oidc_identity_taken")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "oidc_linked")})
+}
+
+// PUBLIC: callback dari provider, menukar code dengan JWT API ini
+func (s *OIDCService) Callback(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	if !s.Client.Config.Enabled {
+		return apperror.NotFound("oidc_disabled")
+	}
+	if e := c.Query("error"); e != "" {
+		return apperror.Unauthorized("oidc_login_cancelled", e)
+	}
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		return apperror.Validation(apperror.Required("state"), apperror.Required("code"))
+	}
+
+	st, err := s.Repo.ConsumeState(ctx, utils.HashToken(state))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.Invalid("oidc_state_invalid")
+		}
+		return apperror.Internal(err)
+	}
+
+	claims, err := s.Client.Exchange(c.UserContext(), code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		return apperror.Unauthorized("oidc_verification_failed").WithErr(err)
+	}
+	if st.LinkUserID != nil {
+		return s.pendingLink(c, *st.LinkUserID, claims)
+	}
+
+	u, err := s.resolveUser(ctx, claims)
+	if err != nil {
+		if err == errOIDCNoAccount {
+			return apperror.Forbidden("oidc_no_account")
+		}
+		return apperror.Wrap(fmt.Errorf("sub=%s: %w", claims.Subject(), err), "oidc_account_mapping_failed")
+	}
+	if u.Disabled {
+		return apperror.Forbidden("account_disabled")
+	}
+
+	totp, err := s.MFA.MFA.GetTOTP(ctx, u.ID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	required, err := s.MFA.mfaRequired(ctx, *u, totp)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if required {
+		return s.MFA.challenge(c, *u, totp)
+	}
+
+	resp, err := s.Tokens.Issue(ctx, *u)
+	if err != nil {
+		return apperror.Wrap(err, "token_issue_failed")
+	}
+	return c.JSON(resp)
+}
+
+// resolveUser memetakan identitas SSO ke baris users, berurutan:
+// identitas yang sudah terhubung, email yang terverifikasi di provider dan
+// di akun lokal, NIM alumni, lalu akun baru (jika JIT provisioning
+// diizinkan). Akun lokal yang belum terverifikasi hanya bisa ditautkan lewat
+// StartLink/ConfirmLink.
+func (s *OIDCService) resolveUser(ctx context.Context, claims oidc.Claims) (*models.User, error) {
+	cfg := s.Client.Config
+	issuer, sub := cfg.Issuer, claims.Subject()
+
+	userID, err := s.Repo.GetIdentityUserID(ctx, issuer, sub)
+	if err == nil {
+		return s.Users.GetUserByID(ctx, userID)
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(claims.String(cfg.EmailClaim))
+	if email != "" && claims.Bool("email_verified") {
+		u, err := s.Users.GetUserByEmail(ctx, email)
+		if err == nil {
+			// akun lokal yang emailnya belum diverifikasi bisa saja didaftarkan
+			// penyerang lebih dulu dengan email korban; jangan diverifikasi atau
+			// ditautkan lewat SSO
+			if !u.EmailVerified {
+				return nil, errOIDCNoAccount
+			}
+			return u, s.Repo.LinkIdentity(ctx, issuer, sub, u.ID)
+		}
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+
+	var alumni *models.Alumni
+	if nim := strings.TrimSpace(claims.String(cfg.NIMClaim)); nim != "" {
+		alumni, err = s.Alumni.GetAlumniByNIM(ctx, nim)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if alumni != nil {
+			u, err := s.Users.GetUserByAlumniID(ctx, alumni.ID)
+			if err == nil {
+				return u, s.Repo.LinkIdentity(ctx, issuer, sub, u.ID)
+			}
+			if err != sql.ErrNoRows {
+				return nil, err
+			}
+		}
+	}
+
+	if !cfg.JITProvision || email == "" {
+		return nil, errOIDCNoAccount
+	}
+	return s.provision(ctx, claims, email, alumni)
+}
+
+// provision membuat akun baru untuk login SSO pertama. Password diisi acak
+// (login password hanya bisa setelah reset password).
+func (s *OIDCService) provision(ctx context.Context, claims oidc.Claims, email string, alumni *models.Alumni) (*models.User, error) {
+	cfg := s.Client.Config
+	username := strings.TrimSpace(claims.String(cfg.UsernameClaim))
+	if username == "" && alumni != nil {
+		username = alumni.NIM
+	}
+	if username == "" {
+		username = strings.SplitN(email, "@", 2)[0]
+	}
+
+	exists, err := s.Users.ExistsByUsernameOrEmail(ctx, username, email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		// email/username sudah dipakai akun lokal yang belum terverifikasi; jangan diambil alih
+		return nil, errOIDCNoAccount
+	}
+	ok, err := s.Roles.Exists(ctx, cfg.DefaultRole)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("OIDC_DEFAULT_ROLE tidak dikenal: " + cfg.DefaultRole)
+	}
+
+	raw, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.HashPassword(raw)
+	if err != nil {
+		return nil, err
+	}
+	// akun dan tautan identitas dibuat bersama: login SSO pertama yang gagal di
+	// tengah jalan tidak meninggalkan akun yatim
+	var u *models.User
+	err = s.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		var err error
+		if u, err = tx.Users.Create(ctx, username, email, hash, cfg.DefaultRole); err != nil {
+			return err
+		}
+		if claims.Bool("email_verified") {
+			if _, err := tx.Users.MarkEmailVerified(ctx, u.ID, u.Email); err != nil {
+				return err
+			}
+			u.EmailVerified = true
+		}
+		if alumni != nil {
+			if _, err := tx.Users.SetAlumniID(ctx, u.ID, &alumni.ID); err != nil {
+				return err
+			}
+			u.AlumniID = &alumni.ID
+		}
+		return tx.OIDC.LinkIdentity(ctx, cfg.Issuer, claims.Subject(), u.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("oidc: akun baru dibuat untuk sub=%s user=%d", claims.Subject(), u.ID)
+	return u, nil
+}
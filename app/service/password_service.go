@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/helper"
+	"go_clean/mailer"
+	"go_clean/utils"
+)
+
+type PasswordService struct {
+	Users    *repository.UserRepository
+	Resets   *repository.PasswordResetRepository
+	Sessions *repository.SessionRepository
+	Mailer   mailer.Mailer
+	Auth     config.AuthConfig
+	BaseURL  string // URL frontend untuk link di email
+	// token, password, dan sesi diubah dalam satu transaksi
+	Tx repository.TxRunner
+}
+
+// PUBLIC: minta link reset password. Respons selalu sama supaya
+// endpoint ini tidak bisa dipakai untuk menebak email yang terdaftar.
+func (s *PasswordService) ForgotPassword(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if !isEmail(req.Email) {
+		return invalidEmail()
+	}
+
+	// respons selalu sama supaya endpoint ini tidak bisa dipakai menebak
+	// email terdaftar; kegagalan hanya dicatat di log
+	ok := fiber.Map{"message": helper.Message(c, "password_reset_requested")}
+
+	u, _, err := s.Users.GetByUsernameOrEmail(ctx, req.Email)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("forgot password: gagal mencari user: %v", err)
+		}
+		return c.JSON(ok)
+	}
+
+	if err := s.sendResetLink(ctx, *u); err != nil {
+		log.Printf("forgot password: gagal kirim link reset ke user %d: %v", u.ID, err)
+	}
+	return c.JSON(ok)
+}
+
+func (s *PasswordService) sendResetLink(ctx context.Context, u models.User) error {
+	raw, err := utils.RandomToken(32)
+	if err != nil {
+		return err
+	}
+	ttl := s.Auth.ResetTokenTTL
+	if err := s.Resets.Create(ctx, u.ID, utils.HashToken(raw), time.Now().Add(ttl)); err != nil {
+		return err
+	}
+
+	link := s.BaseURL + "/reset-password?token=" + url.QueryEscape(raw)
+	return s.Mailer.Send(mailer.Message{
+		To:      u.Email,
+		Subject: "Reset password Alumni API",
+		Body: fmt.Sprintf("Halo %s,\n\nKlik link berikut untuk mengatur ulang password kamu:\n%s\n\n"+
+			"Link ini hanya bisa dipakai sekali dan berlaku %d menit.\n"+
+			"Abaikan email ini jika kamu tidak meminta reset password.\n",
+			u.Username, link, int(ttl.Minutes())),
+	})
+}
+
+// PUBLIC: set password baru memakai token dari email
+func (s *PasswordService) ResetPassword(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	// password dipakai apa adanya, spasi di awal/akhir termasuk bagian password
+	req.Token = strings.TrimSpace(req.Token)
+	if err := required("token", req.Token, "password", req.Password); err != nil {
+		return err
+	}
+	if err := s.checkPolicy(req.Password); err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return apperror.Wrap(err, "password_hash_failed")
+	}
+
+	// token hanya terpakai jika password benar-benar tersimpan
+	err = s.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		userID, err := tx.Resets.Consume(ctx, utils.HashToken(req.Token))
+		if err != nil {
+			return err
+		}
+		if err := tx.Users.UpdatePassword(ctx, userID, hash); err != nil {
+			return err
+		}
+		// password lama mungkin bocor, jadi semua sesi yang ada ikut dicabut
+		return tx.Sessions.RevokeUserSessions(ctx, userID, "")
+	})
+	if err == sql.ErrNoRows {
+		return apperror.Invalid("reset_token_invalid")
+	}
+	if err != nil {
+		return apperror.Wrap(err, "password_save_failed")
+	}
+
+	return c.JSON(fiber.Map{"message": helper.Message(c, "password_reset_done")})
+}
+
+// checkPolicy memeriksa password baru terhadap password policy; setiap
+// aturan yang dilanggar menjadi satu detail field "password"
+func (s *PasswordService) checkPolicy(pw string) error {
+	problems := utils.CheckPasswordPolicy(pw, s.Auth.PasswordPolicy)
+	if len(problems) == 0 {
+		return nil
+	}
+	fields := make([]apperror.FieldError, len(problems))
+	for i, p := range problems {
+		fields[i] = apperror.Field("password", p.Code, p.Args...)
+	}
+	return apperror.Validation(fields...)
+}
+
+// invalidEmail adalah error validasi untuk field email yang formatnya salah
+func invalidEmail() error {
+	return apperror.Validation(apperror.Field("email", "invalid_format"))
+}
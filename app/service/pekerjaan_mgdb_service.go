@@ -8,10 +8,10 @@ import (
 )
 
 type PekerjaanMongoService struct {
-	Repo *repository.PekerjaanMongoRepository
+	Repo repository.PekerjaanMongoStore
 }
 
-func NewPekerjaanMongoService(repo *repository.PekerjaanMongoRepository) *PekerjaanMongoService {
+func NewPekerjaanMongoService(repo repository.PekerjaanMongoStore) *PekerjaanMongoService {
 	return &PekerjaanMongoService{Repo: repo}
 }
 
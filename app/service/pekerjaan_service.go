@@ -1,442 +1,224 @@
 package service
 
 import (
-	"fmt"
-	
+	"context"
 	"database/sql"
+	"go_clean/app/apperror"
 	"go_clean/app/models"
+	"go_clean/app/policy"
 	"go_clean/app/repository"
-	"strconv"
-
-	"github.com/gofiber/fiber/v2"
 )
 
 type PekerjaanService struct {
-	Repo *repository.PekerjaanRepository
+	Repo repository.PekerjaanStore
+	// Tx dipakai untuk tulis + baca ulang dalam satu transaksi
+	Tx repository.StoreTxRunner
 }
 
 // Ambil semua pekerjaan tanpa filter/pagination
-func (s *PekerjaanService) GetAllPekerjaan(c *fiber.Ctx) error {
-	pekerjaan, err := s.Repo.GetAllPekerjaan()
+func (s *PekerjaanService) GetAllPekerjaan(ctx context.Context) ([]models.PekerjaanAlumni, error) {
+	pekerjaan, err := s.Repo.GetAllPekerjaan(ctx)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-		})
+		return nil, apperror.Wrap(err, "pekerjaan_fetch_failed")
 	}
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data pekerjaan berhasil diambil",
-		"data":    pekerjaan,
-	})
+	return pekerjaan, nil
 }
 
 // Ambil pekerjaan berdasarkan ID
-func (s *PekerjaanService) GetPekerjaanByID(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID pekerjaan tidak valid",
-		})
+func (s *PekerjaanService) GetPekerjaanByID(ctx context.Context, id int) (*models.PekerjaanAlumni, error) {
+	pekerjaan, err := s.Repo.GetPekerjaanByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("pekerjaan_not_found")
 	}
-
-	pekerjaan, err := s.Repo.GetPekerjaanByID(id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Pekerjaan tidak ditemukan",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-		})
+		return nil, apperror.Wrap(err, "pekerjaan_fetch_failed")
 	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data pekerjaan berhasil diambil",
-		"data":    pekerjaan,
-	})
+	return pekerjaan, nil
 }
 
 // Ambil list pekerjaan dengan search, sort, pagination (mirip AlumniService)
-func GetPekerjaanList(c *fiber.Ctx) error {
-	sortable := repository.PekerjaanSortable()
-	params := getListParams(c, sortable)
+func (s *PekerjaanService) GetPekerjaanList(ctx context.Context, params ListParams) (models.UserResponse[models.PekerjaanAlumni], error) {
+	params = params.normalize(repository.PekerjaanSortable())
 
-	items, err := repository.ListPekerjaanRepo(params.Search, params.SortBy, params.Order, params.Limit, params.Offset)
+	items, err := s.Repo.ListPekerjaanRepo(ctx, params.Search, params.SortBy, params.Order, params.Limit, params.Offset)
 	if err != nil {
-		fmt.Printf("ListPekerjaanRepo error: %v\n", err)
-		return c.Status(500).JSON(fiber.Map{"error": "failed to fetch pekerjaan"})
+		return models.UserResponse[models.PekerjaanAlumni]{}, apperror.Wrap(err, "pekerjaan_fetch_failed")
 	}
 
-	total, err := repository.CountPekerjaanRepo(params.Search)
+	total, err := s.Repo.CountPekerjaanRepo(ctx, params.Search)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "failed to count pekerjaan"})
-	}
-
-	resp := models.UserResponse[models.PekerjaanAlumni]{
-		Data: items,
-		Meta: models.MetaInfo{
-			Page: params.Page, Limit: params.Limit, Total: total,
-			Pages:  (total + params.Limit - 1) / params.Limit,
-			SortBy: params.SortBy, Order: params.Order, Search: params.Search,
-		},
+		return models.UserResponse[models.PekerjaanAlumni]{}, apperror.Wrap(err, "pekerjaan_count_failed")
 	}
-	return c.JSON(resp)
+	return page(items, total, params), nil
 }
 
 // Ambil semua pekerjaan milik alumni tertentu
-func (s *PekerjaanService) GetPekerjaanByAlumniID(c *fiber.Ctx) error {
-	alumniID, err := strconv.Atoi(c.Params("alumni_id"))
+func (s *PekerjaanService) GetPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.PekerjaanAlumni, error) {
+	pekerjaan, err := s.Repo.GetPekerjaanByAlumniID(ctx, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID alumni tidak valid",
-		})
+		return nil, apperror.Wrap(err, "pekerjaan_fetch_failed")
 	}
+	return pekerjaan, nil
+}
 
-	pekerjaan, err := s.Repo.GetPekerjaanByAlumniID(alumniID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-		})
+// Tambah data pekerjaan baru
+func (s *PekerjaanService) CreatePekerjaan(ctx context.Context, req models.PekerjaanRequest) (*models.PekerjaanAlumni, error) {
+	if err := validatePekerjaan(req); err != nil {
+		return nil, err
 	}
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data pekerjaan untuk alumni berhasil diambil",
-		"data":    pekerjaan,
+
+	// insert dan baca ulang dalam satu transaksi supaya data yang dikembalikan
+	// persis baris yang baru dibuat
+	p := pekerjaanFromRequest(req)
+	var newPekerjaan *models.PekerjaanAlumni
+	err := s.Tx.DoStores(ctx, nil, func(tx repository.Stores) error {
+		newID, err := tx.Pekerjaan.CreatePekerjaan(ctx, &p)
+		if err != nil {
+			return err
+		}
+		newPekerjaan, err = tx.Pekerjaan.GetPekerjaanByID(ctx, newID)
+		return err
 	})
+	if repository.IsForeignKeyViolation(err) {
+		return nil, apperror.Validation(apperror.Field("alumni_id", "not_found"))
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, "pekerjaan_create_failed")
+	}
+	return newPekerjaan, nil
 }
 
-// Tambah data pekerjaan baru
-func (s *PekerjaanService) CreatePekerjaan(c *fiber.Ctx) error {
-	var p models.PekerjaanAlumni
-	if err := c.BodyParser(&p); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Request body tidak valid",
-		})
+// authorize memuat pekerjaan lalu memutuskan apakah principal boleh
+// melakukan action padanya (lihat policy.Pekerjaan)
+func (s *PekerjaanService) authorize(ctx context.Context, principal policy.Principal, action policy.Action, id int) (*models.PekerjaanAlumni, error) {
+	existing, err := s.Repo.GetPekerjaanByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("pekerjaan_not_found")
 	}
-
-	if p.AlumniID == 0 || p.NamaPerusahaan == "" || p.PosisiJabatan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field alumni_id, nama_perusahaan, dan posisi_jabatan wajib diisi",
-		})
+	if err != nil {
+		return nil, apperror.Wrap(err, "permission_check_failed")
 	}
+	if d := policy.Pekerjaan(principal, action, existing); !d.Allowed {
+		return nil, apperror.Forbidden(d.Code)
+	}
+	return existing, nil
+}
 
-	newID, err := s.Repo.CreatePekerjaan(&p)
+// Update data pekerjaan berdasarkan ID; hanya pemilik atau pemegang pekerjaan:write
+func (s *PekerjaanService) UpdatePekerjaan(ctx context.Context, principal policy.Principal, id int, req models.PekerjaanRequest) (*models.PekerjaanAlumni, error) {
+	existing, err := s.authorize(ctx, principal, policy.PekerjaanUpdate, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal menambah pekerjaan: " + err.Error(),
-		})
+		return nil, err
 	}
 
-	newPekerjaan, _ := s.Repo.GetPekerjaanByID(newID)
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "Pekerjaan berhasil ditambahkan",
-		"data":    newPekerjaan,
+	// --- Update dan baca ulang dalam satu transaksi ---
+	p := pekerjaanFromRequest(req)
+	var updated *models.PekerjaanAlumni
+	err = s.Tx.DoStores(ctx, nil, func(tx repository.Stores) error {
+		rows, err := tx.Pekerjaan.UpdatePekerjaan(ctx, existing.ID, &p)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return sql.ErrNoRows
+		}
+		updated, err = tx.Pekerjaan.GetPekerjaanByID(ctx, existing.ID)
+		return err
 	})
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("pekerjaan_not_found")
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, "pekerjaan_update_failed")
+	}
+	return updated, nil
 }
 
-// Update data pekerjaan berdasarkan ID
-func (s *PekerjaanService) UpdatePekerjaan(c *fiber.Ctx) error {
-    // --- Ambil parameter ID pekerjaan ---
-    id, err := strconv.Atoi(c.Params("id"))
-    if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "error":   true,
-            "message": "ID pekerjaan tidak valid",
-        })
-    }
-
-    // --- Ambil data user dari token ---
-    role := c.Locals("role").(string)
-    userID := c.Locals("user_id").(int)
-
-    // --- Ambil user dari DB untuk tahu alumni_id ---
-    userRepo := repository.UserRepository{DB: s.Repo.DB}
-    user, err := userRepo.GetUserByID(userID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "error":   true,
-            "message": "Gagal mengambil data user: " + err.Error(),
-        })
-    }
-
-    // --- Ambil data pekerjaan lama ---
-    existing, err := s.Repo.GetPekerjaanByID(id)
-    if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "error":   true,
-            "message": "Data pekerjaan tidak ditemukan",
-        })
-    }
-
-    // --- Validasi kepemilikan data ---
-    if role == "user" && user.AlumniID != nil && existing.AlumniID != *user.AlumniID {
-        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-            "error":   true,
-            "message": "Kamu tidak punya izin mengubah pekerjaan ini",
-        })
-    }
-
-    // --- Parse request body ---
-    var p models.PekerjaanAlumni
-    if err := c.BodyParser(&p); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "error":   true,
-            "message": "Request body tidak valid",
-        })
-    }
-
-    // --- Set alumni_id otomatis untuk user biasa ---
-    if role == "user" {
-        p.AlumniID = *user.AlumniID
-    }
-
-    // --- Update ke database ---
-    rows, err := s.Repo.UpdatePekerjaan(id, &p)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "error":   true,
-            "message": "Gagal mengupdate pekerjaan: " + err.Error(),
-        })
-    }
-
-    if rows == 0 {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "error":   true,
-            "message": "Pekerjaan tidak ditemukan untuk diupdate",
-        })
-    }
+// Hapus pekerjaan (soft delete); hanya pemilik atau pemegang pekerjaan:write
+func (s *PekerjaanService) DeletePekerjaan(ctx context.Context, principal policy.Principal, id int) error {
+	existing, err := s.authorize(ctx, principal, policy.PekerjaanDelete, id)
+	if err != nil {
+		return err
+	}
 
-    updated, _ := s.Repo.GetPekerjaanByID(id)
-    return c.JSON(fiber.Map{
-        "success": true,
-        "message": "Pekerjaan berhasil diupdate",
-        "data":    updated,
-    })
+	// Soft delete; deleted_by = admin asli jika sedang impersonasi
+	rows, err := s.Repo.SoftDeletePekerjaan(ctx, existing.ID, principal.ActorID)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan_delete_failed")
+	}
+	if rows == 0 {
+		return apperror.NotFound("pekerjaan_not_found")
+	}
+	return nil
 }
 
-
-
-// Hapus pekerjaan (soft delete)
-func (s *PekerjaanService) DeletePekerjaan(c *fiber.Ctx) error {
-    id, err := strconv.Atoi(c.Params("id"))
-    if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "ID pekerjaan tidak valid",
-        })
-    }
-
-    role := c.Locals("role").(string)
-    userID := c.Locals("user_id").(int)
-
-    // Ambil alumni_id dari user login
-    userRepo := repository.UserRepository{DB: s.Repo.DB}
-    user, err := userRepo.GetUserByID(userID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal mengambil data user: " + err.Error(),
-        })
-    }
-
-    // Ambil data pekerjaan
-    existing, err := s.Repo.GetPekerjaanByID(id)
-    if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "Pekerjaan tidak ditemukan",
-        })
-    }
-
-    // Validasi kepemilikan
-    if role == "user" && user.AlumniID != nil && existing.AlumniID != *user.AlumniID  {
-        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-            "success": false,
-            "message": "Kamu tidak memiliki izin menghapus pekerjaan ini",
-        })
-    }
-
-    // Soft delete (gunakan deleted_by sesuai user login)
-    rows, err := s.Repo.SoftDeletePekerjaan(id, userID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal menghapus pekerjaan: " + err.Error(),
-        })
-    }
-
-    if rows == 0 {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "Pekerjaan tidak ditemukan untuk dihapus",
-        })
-    }
-
-    return c.JSON(fiber.Map{
-        "success": true,
-        "message": "Pekerjaan berhasil dihapus (soft delete)",
-    })
+// TrashPekerjaan mengembalikan isi trash: semua pekerjaan untuk pemegang
+// pekerjaan:trash_all, selain itu hanya milik alumni principal
+func (s *PekerjaanService) TrashPekerjaan(ctx context.Context, principal policy.Principal) ([]models.PekerjaanAlumni, error) {
+	var (
+		pekerjaan []models.PekerjaanAlumni
+		err       error
+	)
+	if principal.Can(models.PermPekerjaanTrashAll) {
+		// Admin bisa lihat semua data di trash
+		pekerjaan, err = s.Repo.TrashAllPekerjaan(ctx)
+	} else if principal.AlumniID != nil {
+		// User hanya bisa lihat data miliknya
+		pekerjaan, err = s.Repo.TrashPekerjaanByAlumniID(ctx, *principal.AlumniID)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, "pekerjaan_fetch_failed")
+	}
+	return pekerjaan, nil
 }
 
-
-
-func (s *PekerjaanService) TrashAllPekerjaan(c *fiber.Ctx) error {
-    role := c.Locals("role").(string)
-    userID := c.Locals("user_id").(int)
-
-    // Ambil alumni_id dari tabel users
-    userRepo := repository.UserRepository{DB: s.Repo.DB}
-    user, err := userRepo.GetUserByID(userID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal mengambil data user: " + err.Error(),
-        })
-    }
-
-    var pekerjaan []models.PekerjaanAlumni
-
-    // 🔑 Role-based access
-    if role == "admin" {
-        // Admin bisa lihat semua data di trash
-        pekerjaan, err = s.Repo.TrashAllPekerjaan()
-    } else {
-        // User hanya bisa lihat data miliknya
-        pekerjaan, err = s.Repo.TrashPekerjaanByAlumniID(*user.AlumniID)
-    }
-
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal mengambil data pekerjaan: " + err.Error(),
-        })
-    }
-
-    if len(pekerjaan) == 0 {
-        return c.JSON(fiber.Map{
-            "success": true,
-            "message": "Tidak ada data pekerjaan di trash",
-            "data":    []interface{}{},
-        })
-    }
-
-    return c.JSON(fiber.Map{
-        "success": true,
-        "message": "Data pekerjaan trash berhasil diambil",
-        "data":    pekerjaan,
-    })
+// RestorePekerjaan mengembalikan pekerjaan dari trash
+func (s *PekerjaanService) RestorePekerjaan(ctx context.Context, principal policy.Principal, id int) error {
+	existing, err := s.authorize(ctx, principal, policy.PekerjaanRestore, id)
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.RestorePekerjaanByID(ctx, existing.ID); err != nil {
+		return apperror.Wrap(err, "pekerjaan_restore_failed")
+	}
+	return nil
 }
 
-
-
-func (s *PekerjaanService) RestorePekerjaan(c *fiber.Ctx) error {
-    role := c.Locals("role").(string)
-    userID := c.Locals("user_id").(int)
-    pekerjaanID, err := strconv.Atoi(c.Params("id"))
-    if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "ID pekerjaan tidak valid",
-        })
-    }
-
-    userRepo := repository.UserRepository{DB: s.Repo.DB}
-    user, err := userRepo.GetUserByID(userID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal mengambil data user: " + err.Error(),
-        })
-    }
-
-    existing, err := s.Repo.GetPekerjaanByID(pekerjaanID)
-    if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "Data pekerjaan tidak ditemukan",
-        })
-    }
-
-    if role == "user" && user.AlumniID != nil && existing.AlumniID != *user.AlumniID {
-        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-            "success": false,
-            "message": "Kamu tidak punya izin untuk me-restore pekerjaan ini",
-        })
-    }
-
-    err = s.Repo.RestorePekerjaanByID(pekerjaanID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal me-restore pekerjaan: " + err.Error(),
-        })
-    }
-
-    return c.JSON(fiber.Map{
-        "success": true,
-        "message": "Pekerjaan berhasil di-restore",
-    })
+// HardDeletePekerjaan menghapus permanen pekerjaan yang sudah di trash
+func (s *PekerjaanService) HardDeletePekerjaan(ctx context.Context, principal policy.Principal, id int) error {
+	existing, err := s.authorize(ctx, principal, policy.PekerjaanHardDelete, id)
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.HardDeletePekerjaanByID(ctx, existing.ID); err != nil {
+		return apperror.Wrap(err, "pekerjaan_hard_delete_failed")
+	}
+	return nil
 }
 
+// validatePekerjaan memeriksa field wajib dan melaporkan semua yang kosong
+func validatePekerjaan(req models.PekerjaanRequest) error {
+	var fields []apperror.FieldError
+	if req.AlumniID == 0 {
+		fields = append(fields, apperror.Required("alumni_id"))
+	}
+	if req.NamaPerusahaan == "" {
+		fields = append(fields, apperror.Required("nama_perusahaan"))
+	}
+	if req.PosisiJabatan == "" {
+		fields = append(fields, apperror.Required("posisi_jabatan"))
+	}
+	if fields != nil {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
 
-
-func (s *PekerjaanService) HardDeletePekerjaan(c *fiber.Ctx) error {
-    role := c.Locals("role").(string)
-    userID := c.Locals("user_id").(int)
-    pekerjaanID, err := strconv.Atoi(c.Params("id"))
-    if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "ID pekerjaan tidak valid",
-        })
-    }
-
-    userRepo := repository.UserRepository{DB: s.Repo.DB}
-    user, err := userRepo.GetUserByID(userID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal mengambil data user: " + err.Error(),
-        })
-    }
-
-    existing, err := s.Repo.GetPekerjaanByID(pekerjaanID)
-    if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "Pekerjaan tidak ditemukan",
-        })
-    }
-
-    if role == "user" && user.AlumniID != nil && existing.AlumniID != *user.AlumniID {
-        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-            "success": false,
-            "message": "Kamu tidak memiliki izin menghapus permanen pekerjaan ini",
-        })
-    }
-
-    err = s.Repo.HardDeletePekerjaanByID(pekerjaanID)
-    if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal menghapus permanen pekerjaan: " + err.Error(),
-        })
-    }
-
-    return c.JSON(fiber.Map{
-        "success": true,
-        "message": "Pekerjaan berhasil dihapus permanen",
-    })
-}
\ No newline at end of file
+func pekerjaanFromRequest(req models.PekerjaanRequest) models.PekerjaanAlumni {
+	return models.PekerjaanAlumni{
+		AlumniID: req.AlumniID, NamaPerusahaan: req.NamaPerusahaan, PosisiJabatan: req.PosisiJabatan,
+		BidangIndustri: req.BidangIndustri, LokasiKerja: req.LokasiKerja, GajiRange: req.GajiRange,
+		TanggalMulaiKerja: req.TanggalMulaiKerja, TanggalSelesaiKerja: req.TanggalSelesaiKerja,
+		StatusPekerjaan: req.StatusPekerjaan, DeskripsiPekerjaan: req.DeskripsiPekerjaan,
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/helper"
+)
+
+type RoleService struct {
+	Repo *repository.RoleRepository
+	Tx   repository.TxRunner
+}
+
+func (s *RoleService) GetAllRoles(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	roles, err := s.Repo.GetAllRoles(ctx)
+	if err != nil {
+		return apperror.Wrap(err, "role_fetch_failed")
+	}
+	return c.JSON(fiber.Map{"data": roles})
+}
+
+func (s *RoleService) GetAllPermissions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	perms, err := s.Repo.GetAllPermissions(ctx)
+	if err != nil {
+		return apperror.Wrap(err, "permission_fetch_failed")
+	}
+	return c.JSON(fiber.Map{"data": perms})
+}
+
+// SaveRole membuat role baru atau mengganti permission role yang sudah ada.
+// Perubahan berlaku untuk token yang diterbitkan setelahnya (login/refresh berikutnya).
+// Perubahan yang membuat tidak ada user aktif memegang users:manage ditolak,
+// supaya admin tidak bisa mengunci semua admin keluar.
+func (s *RoleService) SaveRole(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req models.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.InvalidPayload()
+	}
+	name := strings.ToLower(strings.TrimSpace(c.Params("name")))
+	if name == "" {
+		return apperror.Validation(apperror.Required("name"))
+	}
+
+	valid := make(map[string]bool)
+	for _, p := range models.BuiltinPermissions() {
+		valid[p.Name] = true
+	}
+	// duplikat dibuang supaya tidak menabrak primary key role_permissions
+	perms := make([]string, 0, len(req.Permissions))
+	seen := make(map[string]bool, len(req.Permissions))
+	for _, p := range req.Permissions {
+		p = strings.TrimSpace(p)
+		if !valid[p] {
+			return apperror.Invalid("unknown_permission", p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			perms = append(perms, p)
+		}
+	}
+
+	role := models.Role{Name: name, Description: strings.TrimSpace(req.Description), Permissions: perms}
+	// penyimpanan grant dan cek pemegang users:manage dalam satu transaksi
+	// SERIALIZABLE, supaya dua edit bersamaan tidak sama-sama lolos
+	err := s.Tx.Do(ctx, serializable, func(tx repository.Repositories) error {
+		if err := tx.Roles.SaveRole(ctx, role); err != nil {
+			return err
+		}
+		if seen[models.PermUsersManage] {
+			return nil
+		}
+		held, err := tx.Roles.HasActiveHolder(ctx, models.PermUsersManage)
+		if err != nil {
+			return err
+		}
+		if !held {
+			return apperror.Conflict("role_last_holder", models.PermUsersManage)
+		}
+		return nil
+	})
+	if k := apperror.KindOf(err); k == apperror.KindInvalid || k == apperror.KindConflict {
+		return err
+	}
+	if err != nil {
+		return apperror.Wrap(err, "role_save_failed")
+	}
+	return c.JSON(fiber.Map{"message": helper.Message(c, "role_saved"), "data": role})
+}
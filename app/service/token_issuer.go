@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/utils"
+)
+
+// TokenIssuer membuka sesi lalu menerbitkan access + refresh token.
+// Permission role ikut ditanam di access token supaya middleware.Require
+// tidak perlu query ke database di setiap request.
+type TokenIssuer struct {
+	Sessions *repository.SessionRepository
+	Roles    *repository.RoleRepository
+	Auth     config.AuthConfig
+	JWT      *utils.JWT
+}
+
+// Issue membuka sesi baru untuk user (login, register, dst)
+func (t *TokenIssuer) Issue(ctx context.Context, u models.User) (*models.LoginResponse, error) {
+	sessionID, err := t.Sessions.CreateSession(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	raw, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Sessions.CreateRefreshToken(ctx, sessionID, hash, time.Now().Add(t.JWT.Config().RefreshTTL)); err != nil {
+		return nil, err
+	}
+	return t.respond(ctx, u, sessionID, raw)
+}
+
+// Impersonate membuka sesi atas nama u untuk admin actor. Token berisi
+// permission milik u (supaya admin melihat persis yang dilihat u) dan claim act;
+// tidak ada refresh token, jadi sesi berakhir saat token kedaluwarsa.
+func (t *TokenIssuer) Impersonate(ctx context.Context, u, actor models.User) (*models.LoginResponse, error) {
+	sessionID, err := t.Sessions.CreateImpersonationSession(ctx, u.ID, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := t.Roles.PermissionsForRole(ctx, u.Role)
+	if err != nil {
+		return nil, err
+	}
+	ttl := t.Auth.ImpersonationTTL
+	tok, err := t.JWT.GenerateImpersonationToken(u, sessionID, perms, actor, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LoginResponse{
+		User:      u,
+		Token:     tok,
+		ExpiresIn: int(ttl.Seconds()),
+	}, nil
+}
+
+// respond menerbitkan access token untuk sesi yang sudah ada
+func (t *TokenIssuer) respond(ctx context.Context, u models.User, sessionID, refreshToken string) (*models.LoginResponse, error) {
+	perms, err := t.Roles.PermissionsForRole(ctx, u.Role)
+	if err != nil {
+		return nil, err
+	}
+	tok, err := t.JWT.GenerateToken(u, sessionID, perms)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LoginResponse{
+		User:         u,
+		Token:        tok,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(t.JWT.Config().AccessTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"go_clean/app/apperror"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+)
+
+// Aksi admin untuk /api/users (butuh permission users:manage). actorID adalah
+// admin yang melakukan aksi, dipakai untuk menolak aksi terhadap akun sendiri.
+
+func (s *UserService) GetUser(ctx context.Context, id int) (*models.User, error) {
+	u, err := s.Repo.GetUserByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("user_not_found")
+	}
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return u, nil
+}
+
+// UpdateUserRole mengganti role; sesi user dicabut supaya permission baru langsung berlaku
+func (s *UserService) UpdateUserRole(ctx context.Context, actorID, id int, req models.UpdateUserRoleRequest) (*models.User, error) {
+	if actorID == id {
+		return nil, apperror.Invalid("cannot_change_own_role")
+	}
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+
+	err := s.Tx.Do(ctx, serializable, func(tx repository.Repositories) error {
+		exists, err := tx.Roles.Exists(ctx, role)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperror.Invalid("unknown_role")
+		}
+		n, err := tx.Users.UpdateRole(ctx, id, role)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return sql.ErrNoRows
+		}
+		return tx.Sessions.RevokeUserSessions(ctx, id, "")
+	})
+	if apperror.KindOf(err) == apperror.KindInvalid {
+		return nil, err
+	}
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("user_not_found")
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, "role_update_failed")
+	}
+	return s.GetUser(ctx, id)
+}
+
+// SetDisabled menonaktifkan/mengaktifkan akun; menonaktifkan juga mencabut semua sesi
+func (s *UserService) SetDisabled(ctx context.Context, actorID, id int, disabled bool) (*models.User, error) {
+	if actorID == id {
+		return nil, apperror.Invalid("cannot_disable_self")
+	}
+
+	n, err := s.Repo.SetDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if n == 0 {
+		return nil, apperror.NotFound("user_not_found")
+	}
+	if disabled {
+		if err := s.Sessions.RevokeUserSessions(ctx, id, ""); err != nil {
+			return nil, apperror.Wrap(err, "session_revoke_failed")
+		}
+	}
+	return s.GetUser(ctx, id)
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, actorID, id int) error {
+	if actorID == id {
+		return apperror.Invalid("cannot_delete_self")
+	}
+
+	n, err := s.Repo.DeleteUser(ctx, id)
+	if repository.IsForeignKeyViolation(err) {
+		// masih direferensikan data tanpa ON DELETE
+		return apperror.Conflict("user_in_use").WithErr(err)
+	}
+	if err != nil {
+		return apperror.Wrap(err, "user_delete_failed")
+	}
+	if n == 0 {
+		return apperror.NotFound("user_not_found")
+	}
+	return nil
+}
+
+// ForcePasswordReset mewajibkan user mengganti password: login diblokir,
+// semua sesi dicabut, dan link reset dikirim ke email user.
+func (s *UserService) ForcePasswordReset(ctx context.Context, id int) error {
+	u, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.Repo.SetPasswordResetRequired(ctx, id, true); err != nil {
+		return apperror.Internal(err)
+	}
+	if err := s.Sessions.RevokeUserSessions(ctx, id, ""); err != nil {
+		return apperror.Wrap(err, "session_revoke_failed")
+	}
+	if err := s.Passwords.sendResetLink(ctx, *u); err != nil {
+		return apperror.Wrap(fmt.Errorf("user %d: %w", u.ID, err), "reset_email_failed")
+	}
+	return nil
+}
+
+// LinkAlumni menautkan akun ke data alumni (alumni_id null = lepas tautan)
+func (s *UserService) LinkAlumni(ctx context.Context, id int, req models.LinkAlumniRequest) (*models.User, error) {
+	if req.AlumniID != nil {
+		if _, err := s.Alumni.GetAlumniByID(ctx, *req.AlumniID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("alumni_not_found")
+			}
+			return nil, apperror.Internal(err)
+		}
+		owner, err := s.Repo.GetUserByAlumniID(ctx, *req.AlumniID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, apperror.Internal(err)
+		}
+		if owner != nil && owner.ID != id {
+			return nil, apperror.Conflict("alumni_already_linked")
+		}
+	}
+
+	n, err := s.Repo.SetAlumniID(ctx, id, req.AlumniID)
+	if err != nil {
+		return nil, apperror.Wrap(err, "alumni_link_failed")
+	}
+	if n == 0 {
+		return nil, apperror.NotFound("user_not_found")
+	}
+	return s.GetUser(ctx, id)
+}
+
+// Impersonate menerbitkan token singkat untuk melihat aplikasi sebagai user lain.
+// Aksi tulis selama impersonasi dicatat ke audit_logs atas nama admin.
+func (s *UserService) Impersonate(ctx context.Context, actorID, id int) (*models.LoginResponse, error) {
+	if actorID == id {
+		return nil, apperror.Invalid("cannot_impersonate_self")
+	}
+	target, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if target.Disabled {
+		return nil, apperror.Invalid("account_disabled")
+	}
+	perms, err := s.Roles.PermissionsForRole(ctx, target.Role)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if models.IsPrivileged(perms) {
+		return nil, apperror.Forbidden("impersonate_admin_not_allowed")
+	}
+
+	actor, err := s.Repo.GetUserByID(ctx, actorID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	resp, err := s.Tokens.Impersonate(ctx, *target, *actor)
+	if err != nil {
+		return nil, apperror.Wrap(err, "token_issue_failed")
+	}
+	log.Printf("impersonasi dimulai: admin=%d user=%d", actor.ID, target.ID)
+	return resp, nil
+}
@@ -1,65 +1,42 @@
 package service
 
 import (
+	"context"
 	"net/mail"
-	"strconv" 
-    "strings"
+	"strings"
 
-	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
 	"go_clean/app/models"
 	"go_clean/app/repository"
 	"go_clean/utils"
 )
 
 type UserService struct {
-	Repo *repository.UserRepository
+	Repo      *repository.UserRepository
+	Roles     *repository.RoleRepository
+	Alumni    repository.AlumniStore
+	Sessions  *repository.SessionRepository
+	Tokens    *TokenIssuer
+	Passwords *PasswordService
+	// akun baru mulai belum terverifikasi dan dikirimi link verifikasi
+	Verification *EmailVerificationService
+	Tx           repository.TxRunner
 }
 
-func (s *UserService) GetUsersService(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	sortBy := c.Query("sortBy", "id")
-	order := c.Query("order", "asc")
-	search := c.Query("search", "")
-	offset := (page - 1) * limit
+// ADMIN: list user dengan search, sort, pagination (ListParams yang sama dengan alumni/pekerjaan)
+func (s *UserService) ListUsers(ctx context.Context, params ListParams) (models.UserResponse[models.User], error) {
+	params = params.normalize(repository.UsersSortable())
 
-	// Validasi input
-	sortByWhitelist := map[string]bool{"id": true, "name": true,
-		"email": true, "created_at": true}
-	if !sortByWhitelist[sortBy] {
-		sortBy = "id"
-	}
-	if strings.ToLower(order) != "desc" {
-		order = "asc"
-	}
-
-	// Ambil data dari repository
-	users, err := s.Repo.GetUsersRepo(search, sortBy, order,
-		limit, offset)
+	users, err := s.Repo.GetUsersRepo(ctx, params.Search, params.SortBy, params.Order, params.Limit, params.Offset)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch users"})
+		return models.UserResponse[models.User]{}, apperror.Wrap(err, "user_fetch_failed")
 	}
 
-	total, err := s.Repo.CountUsersRepo(search)
+	total, err := s.Repo.CountUsersRepo(ctx, params.Search)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to count users"})
+		return models.UserResponse[models.User]{}, apperror.Wrap(err, "user_count_failed")
 	}
-
-	// Buat response pakai model
-	response := models.UserResponse[models.User]{
-		Data: users,
-		Meta: models.MetaInfo{
-			Page:   page,
-			Limit:  limit,
-			Total:  total,
-			Pages:  (total + limit - 1) / limit,
-			SortBy: sortBy,
-			Order:  order,
-			Search: search,
-		},
-	}
-
-	return c.JSON(response)
+	return page(users, total, params), nil
 }
 
 // helper validasi ringan
@@ -68,94 +45,89 @@ func isEmail(s string) bool {
 	return err == nil
 }
 
-// PUBLIC: register user (role = "user" fixed)
-func (s *UserService) RegisterUser(c *fiber.Ctx) error {
-	var req models.RegisterRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "payload tidak valid"})
-	}
+// PUBLIC: register user (role = "user" fixed). User langsung login, tapi
+// token hanya bisa membaca sampai email terverifikasi.
+func (s *UserService) Register(ctx context.Context, req models.RegisterRequest) (*models.User, *models.LoginResponse, error) {
 	req.Username = strings.TrimSpace(req.Username)
 	req.Email = strings.TrimSpace(req.Email)
-	req.Password = strings.TrimSpace(req.Password)
+	// password tidak di-trim: Login, ganti password, dan reset memakainya apa adanya
 
-	if req.Username == "" || req.Email == "" || req.Password == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "username, email, password wajib"})
+	if err := required("username", req.Username, "email", req.Email, "password", req.Password); err != nil {
+		return nil, nil, err
 	}
 	if !isEmail(req.Email) {
-		return c.Status(400).JSON(fiber.Map{"error": "format email tidak valid"})
+		return nil, nil, invalidEmail()
+	}
+	if err := s.Passwords.checkPolicy(req.Password); err != nil {
+		return nil, nil, err
 	}
-	exists, err := s.Repo.ExistsByUsernameOrEmail(req.Username, req.Email)
+	exists, err := s.Repo.ExistsByUsernameOrEmail(ctx, req.Username, req.Email)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "db error"})
+		return nil, nil, apperror.Internal(err)
 	}
 	if exists {
-		return c.Status(409).JSON(fiber.Map{"error": "username/email sudah dipakai"})
+		return nil, nil, apperror.Conflict("user_exists")
 	}
 
 	hash, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "gagal hash password"})
+		return nil, nil, apperror.Wrap(err, "password_hash_failed")
 	}
 
-	u, err := s.Repo.Create(req.Username, req.Email, hash, "user")
+	u, err := s.Repo.Create(ctx, req.Username, req.Email, hash, "user")
 	if err != nil {
 		// cek duplikat juga bisa terjadi dari constraint
-		return c.Status(500).JSON(fiber.Map{"error": "gagal membuat user"})
+		return nil, nil, apperror.Wrap(err, "user_create_failed")
 	}
+	s.Verification.sendAfterSignup(ctx, *u)
 
-	// opsional: langsung login (return token)
-	token, err := utils.GenerateToken(*u)
+	tokens, err := s.Tokens.Issue(ctx, *u)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "gagal generate token"})
+		return nil, nil, apperror.Wrap(err, "token_issue_failed")
 	}
-	return c.Status(201).JSON(fiber.Map{
-		"message": "register sukses",
-		"user":    u,
-		"token":   token,
-	})
+	return u, tokens, nil
 }
 
-// ADMIN ONLY: create user/admin
-func (s *UserService) AdminCreateUser(c *fiber.Ctx) error {
-	// pastikan middleware AdminOnly sudah pasang di router
-	var req models.AdminCreateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "payload tidak valid"})
-	}
+// ADMIN ONLY: create user/admin (route harus memasang Require(users:manage))
+func (s *UserService) AdminCreateUser(ctx context.Context, req models.AdminCreateUserRequest) (*models.User, error) {
 	req.Username = strings.TrimSpace(req.Username)
 	req.Email = strings.TrimSpace(req.Email)
-	req.Password = strings.TrimSpace(req.Password)
 	req.Role = strings.ToLower(strings.TrimSpace(req.Role))
 
-	if req.Username == "" || req.Email == "" || req.Password == "" || req.Role == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "username, email, password, role wajib"})
+	if err := required("username", req.Username, "email", req.Email, "password", req.Password, "role", req.Role); err != nil {
+		return nil, err
 	}
 	if !isEmail(req.Email) {
-		return c.Status(400).JSON(fiber.Map{"error": "format email tidak valid"})
+		return nil, invalidEmail()
+	}
+	if err := s.Passwords.checkPolicy(req.Password); err != nil {
+		return nil, err
+	}
+	roleExists, err := s.Roles.Exists(ctx, req.Role)
+	if err != nil {
+		return nil, apperror.Internal(err)
 	}
-	if req.Role != "admin" && req.Role != "user" {
-		return c.Status(400).JSON(fiber.Map{"error": "role harus 'admin' atau 'user'"})
+	if !roleExists {
+		return nil, apperror.Invalid("unknown_role")
 	}
 
-	exists, err := s.Repo.ExistsByUsernameOrEmail(req.Username, req.Email)
+	exists, err := s.Repo.ExistsByUsernameOrEmail(ctx, req.Username, req.Email)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "db error"})
+		return nil, apperror.Internal(err)
 	}
 	if exists {
-		return c.Status(409).JSON(fiber.Map{"error": "username/email sudah dipakai"})
+		return nil, apperror.Conflict("user_exists")
 	}
 
 	hash, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "gagal hash password"})
+		return nil, apperror.Wrap(err, "password_hash_failed")
 	}
 
-	u, err := s.Repo.Create(req.Username, req.Email, hash, req.Role)
+	u, err := s.Repo.Create(ctx, req.Username, req.Email, hash, req.Role)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "gagal membuat user"})
+		return nil, apperror.Wrap(err, "user_create_failed")
 	}
-	return c.Status(201).JSON(fiber.Map{
-		"message": "user dibuat",
-		"user":    u,
-	})
+	s.Verification.sendAfterSignup(ctx, *u)
+	return u, nil
 }
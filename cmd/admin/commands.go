@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go_clean/app/apperror"
+	"go_clean/app/i18n"
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/utils"
+)
+
+// sumber aksi yang dicatat di lockout_events untuk operasi lewat CLI
+const cliIP = "cli"
+
+// findUser mencari user berdasarkan ID, username, atau email. Identifier
+// angka dicoba sebagai ID dulu, lalu sebagai username (username boleh
+// berupa angka); jika keduanya cocok ke user berbeda, operator harus memakai
+// email.
+func findUser(ctx context.Context, r *cli, ident string) (*models.User, error) {
+	if ident == "" {
+		return nil, errors.New("-user wajib diisi")
+	}
+	var byID *models.User
+	if id, convErr := strconv.Atoi(ident); convErr == nil {
+		u, err := r.Users.GetUserByID(ctx, id)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		byID = u
+	}
+
+	u, _, err := r.Users.GetByUsernameOrEmail(ctx, ident)
+	switch {
+	case err == sql.ErrNoRows && byID == nil:
+		return nil, fmt.Errorf("user %q tidak ditemukan", ident)
+	case err == sql.ErrNoRows:
+		return byID, nil
+	case err != nil:
+		return nil, err
+	case byID != nil && byID.ID != u.ID:
+		return nil, fmt.Errorf("%q ambigu: ID user %d dan username user %d, pakai email", ident, byID.ID, u.ID)
+	}
+	return u, nil
+}
+
+// choosePassword memvalidasi password dari flag, atau membuat password acak
+// jika kosong. generated=true berarti password perlu dicetak ke operator.
+func choosePassword(pw string, policy config.PasswordPolicy) (string, bool, error) {
+	if pw == "" {
+		raw, err := utils.RandomToken(12)
+		if err != nil {
+			return "", false, err
+		}
+		// huruf besar + simbol supaya lolos policy seketat apa pun
+		return "A" + raw + "!", true, nil
+	}
+	if problems := utils.CheckPasswordPolicy(pw, policy); len(problems) > 0 {
+		msgs := make([]string, len(problems))
+		for i, p := range problems {
+			msgs[i] = apperror.Field("password", p.Code, p.Args...).Localize(i18n.Default).Message
+		}
+		return "", false, errors.New(strings.Join(msgs, "; "))
+	}
+	return pw, false, nil
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(os.Stderr)
+	return fs.Parse(args)
+}
+
+func createAdmin(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := fs.String("username", "", "username admin (wajib)")
+	email := fs.String("email", "", "email admin (wajib)")
+	password := fs.String("password", "", "password; kosong = dibuat acak dan dicetak")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *username == "" || *email == "" {
+		return errors.New("-username dan -email wajib diisi")
+	}
+
+	exists, err := r.Users.ExistsByUsernameOrEmail(ctx, *username, *email)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("username atau email sudah dipakai")
+	}
+	pw, generated, err := choosePassword(*password, r.Auth.PasswordPolicy)
+	if err != nil {
+		return err
+	}
+	// database baru mungkin belum pernah menjalankan server
+	if err := r.Roles.EnsureBuiltinRoles(ctx); err != nil {
+		return err
+	}
+	hash, err := utils.HashPassword(pw)
+	if err != nil {
+		return err
+	}
+	var u *models.User
+	err = r.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		var err error
+		if u, err = tx.Users.Create(ctx, *username, *email, hash, "admin"); err != nil {
+			return err
+		}
+		// admin dibuat operator, emailnya dianggap sudah terverifikasi
+		_, err = tx.Users.MarkEmailVerified(ctx, u.ID, u.Email)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("admin %s (id %d) berhasil dibuat\n", u.Username, u.ID)
+	if generated {
+		fmt.Printf("password: %s\n", pw)
+	}
+	return nil
+}
+
+func resetPassword(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("reset-password", flag.ContinueOnError)
+	ident := fs.String("user", "", "username, email, atau ID user")
+	password := fs.String("password", "", "password baru; kosong = dibuat acak dan dicetak")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	u, err := findUser(ctx, r, *ident)
+	if err != nil {
+		return err
+	}
+	pw, generated, err := choosePassword(*password, r.Auth.PasswordPolicy)
+	if err != nil {
+		return err
+	}
+	hash, err := utils.HashPassword(pw)
+	if err != nil {
+		return err
+	}
+	err = r.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		if err := tx.Users.UpdatePassword(ctx, u.ID, hash); err != nil {
+			return err
+		}
+		// sama seperti reset lewat email: semua sesi lama dicabut
+		return tx.Sessions.RevokeUserSessions(ctx, u.ID, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("password %s diperbarui, semua sesi dicabut\n", u.Username)
+	if generated {
+		fmt.Printf("password: %s\n", pw)
+	}
+	return nil
+}
+
+func setRole(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("set-role", flag.ContinueOnError)
+	ident := fs.String("user", "", "username, email, atau ID user")
+	role := fs.String("role", "", "nama role tujuan")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	u, err := findUser(ctx, r, *ident)
+	if err != nil {
+		return err
+	}
+	exists, err := r.Roles.Exists(ctx, *role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("role %q tidak dikenal", *role)
+	}
+	err = r.Tx.Do(ctx, nil, func(tx repository.Repositories) error {
+		if _, err := tx.Users.UpdateRole(ctx, u.ID, *role); err != nil {
+			return err
+		}
+		// permission tertanam di access token, jadi sesi lama harus dicabut
+		return tx.Sessions.RevokeUserSessions(ctx, u.ID, "")
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("role %s: %s -> %s, semua sesi dicabut\n", u.Username, u.Role, strings.ToLower(*role))
+	return nil
+}
+
+func unlockUser(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("unlock-user", flag.ContinueOnError)
+	ident := fs.String("user", "", "username, email, atau ID user")
+	ip := fs.String("ip", "", "juga buka kunci alamat IP ini (opsional)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	u, err := findUser(ctx, r, *ident)
+	if err != nil {
+		return err
+	}
+
+	key := strconv.Itoa(u.ID)
+	if err := r.Throttles.Reset(ctx, models.LockScopeAccount, key); err != nil {
+		return err
+	}
+	if err := r.Throttles.LogEvent(ctx, &models.LockoutEvent{
+		Scope: models.LockScopeAccount, Key: key, UserID: &u.ID, Event: "unlocked", IP: cliIP,
+	}); err != nil {
+		return err
+	}
+	if *ip != "" {
+		if err := r.Throttles.Reset(ctx, models.LockScopeIP, *ip); err != nil {
+			return err
+		}
+		if err := r.Throttles.LogEvent(ctx, &models.LockoutEvent{
+			Scope: models.LockScopeIP, Key: *ip, Event: "unlocked", IP: cliIP,
+		}); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("akun %s berhasil dibuka\n", u.Username)
+	return nil
+}
+
+func linkAlumni(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("link-alumni", flag.ContinueOnError)
+	ident := fs.String("user", "", "username, email, atau ID user")
+	nim := fs.String("nim", "", "NIM alumni yang ditautkan")
+	alumniID := fs.Int("alumni-id", 0, "ID alumni yang ditautkan (alternatif -nim)")
+	unlink := fs.Bool("unlink", false, "lepas tautan alumni")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	u, err := findUser(ctx, r, *ident)
+	if err != nil {
+		return err
+	}
+
+	if *unlink {
+		if _, err := r.Users.SetAlumniID(ctx, u.ID, nil); err != nil {
+			return err
+		}
+		fmt.Printf("tautan alumni %s dilepas\n", u.Username)
+		return nil
+	}
+
+	var a *models.Alumni
+	switch {
+	case *nim != "":
+		a, err = r.Alumni.GetAlumniByNIM(ctx, *nim)
+	case *alumniID > 0:
+		a, err = r.Alumni.GetAlumniByID(ctx, *alumniID)
+	default:
+		return errors.New("isi -nim, -alumni-id, atau -unlink")
+	}
+	if err == sql.ErrNoRows {
+		return errors.New("alumni tidak ditemukan")
+	}
+	if err != nil {
+		return err
+	}
+
+	owner, err := r.Users.GetUserByAlumniID(ctx, a.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if owner != nil && owner.ID != u.ID {
+		return fmt.Errorf("alumni sudah tertaut ke akun lain (%s)", owner.Username)
+	}
+	if _, err := r.Users.SetAlumniID(ctx, u.ID, &a.ID); err != nil {
+		return err
+	}
+	fmt.Printf("%s ditautkan ke alumni %s (%s)\n", u.Username, a.NIM, a.Nama)
+	return nil
+}
+
+func listUsers(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("list-users", flag.ContinueOnError)
+	search := fs.String("search", "", "filter username/email")
+	limit := fs.Int("limit", 50, "jumlah baris per halaman")
+	page := fs.Int("page", 1, "nomor halaman")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		*limit = 50
+	}
+	if *page <= 0 {
+		*page = 1
+	}
+
+	users, err := r.Users.GetUsersRepo(ctx, *search, "id", "asc", *limit, (*page-1)*(*limit))
+	if err != nil {
+		return err
+	}
+	total, err := r.Users.CountUsersRepo(ctx, *search)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUSERNAME\tEMAIL\tROLE\tALUMNI\tSTATUS")
+	for _, u := range users {
+		alumni := "-"
+		if u.AlumniID != nil {
+			alumni = strconv.Itoa(*u.AlumniID)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", u.ID, u.Username, u.Email, u.Role, alumni, userStatus(u))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("halaman %d, %d dari %d user\n", *page, len(users), total)
+	return nil
+}
+
+func userStatus(u models.User) string {
+	var s []string
+	if u.Disabled {
+		s = append(s, "nonaktif")
+	}
+	if u.PasswordResetRequired {
+		s = append(s, "wajib-reset")
+	}
+	if !u.EmailVerified {
+		s = append(s, "email-belum-verifikasi")
+	}
+	if len(s) == 0 {
+		return "aktif"
+	}
+	return strings.Join(s, ",")
+}
+
+func purgeTrash(ctx context.Context, r *cli, args []string) error {
+	fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	days := fs.Int("older-than", 30, "hapus pekerjaan yang sudah di trash lebih dari N hari")
+	dryRun := fs.Bool("dry-run", false, "hanya hitung, tidak menghapus")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *days < 0 {
+		return errors.New("-older-than tidak boleh negatif")
+	}
+	before := time.Now().AddDate(0, 0, -*days)
+
+	if *dryRun {
+		n, err := r.Pekerjaan.CountTrashedPekerjaanBefore(ctx, before)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d pekerjaan akan dihapus permanen (dihapus sebelum %s)\n", n, before.Format(time.DateTime))
+		return nil
+	}
+	n, err := r.Pekerjaan.PurgeTrashedPekerjaanBefore(ctx, before)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d pekerjaan dihapus permanen\n", n)
+	return nil
+}
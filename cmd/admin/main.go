@@ -0,0 +1,104 @@
+// admin adalah CLI operasional untuk memperbaiki akun tanpa SQL manual.
+//
+//	go run ./cmd/admin create-admin -username ops -email ops@kampus.ac.id
+//	go run ./cmd/admin reset-password -user budi
+//	go run ./cmd/admin set-role -user budi -role staff
+//	go run ./cmd/admin unlock-user -user budi [-ip 10.0.0.5]
+//	go run ./cmd/admin link-alumni -user budi -nim 214110001   (atau -unlink)
+//	go run ./cmd/admin list-users [-search budi] [-limit 50] [-page 1]
+//	go run ./cmd/admin purge-trash [-older-than 30] [-dry-run]
+//
+// -user menerima username, email, atau ID. Konfigurasi dibaca sama seperti
+// server (file -config, .env, environment); flag config ditulis sebelum perintah:
+//
+//	go run ./cmd/admin -config config.yaml list-users
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/database"
+)
+
+// cli berisi repository (dari koneksi yang sama dengan server) dan config
+type cli struct {
+	repository.Repositories
+	Tx   *repository.UnitOfWork
+	Auth config.AuthConfig
+}
+
+type command struct {
+	summary string
+	run     func(ctx context.Context, r *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"create-admin":   {"buat akun admin baru", createAdmin},
+	"reset-password": {"set password baru dan cabut semua sesi user", resetPassword},
+	"set-role":       {"ganti role user dan cabut semua sesinya", setRole},
+	"unlock-user":    {"buka kunci login akun (dan opsional IP)", unlockUser},
+	"link-alumni":    {"tautkan/lepas akun user dari data alumni", linkAlumni},
+	"list-users":     {"tampilkan daftar user", listUsers},
+	"purge-trash":    {"hapus permanen pekerjaan yang lama di trash", purgeTrash},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "pemakaian: admin [flag config] <perintah> [flag]\n\nperintah:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr, "\njalankan 'admin <perintah> -h' untuk flag tiap perintah\n\nflag config:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	cfgFlags := config.BindFlags(flag.CommandLine)
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(cfgFlags)
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	r := &cli{
+		Repositories: repository.NewRepositories(db),
+		Tx:           &repository.UnitOfWork{DB: db},
+		Auth:         cfg.Auth,
+	}
+	// Ctrl-C membatalkan query yang sedang berjalan
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := cmd.run(ctx, r, args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,92 @@
+// genkey membuat key penandatangan JWT baru di folder key set.
+//
+//	go run ./cmd/genkey -alg RS256 -kid 2025-01 -out keys
+//	go run ./cmd/genkey -alg EdDSA -kid 2025-02 -out keys
+//
+// Saat rotasi, private key lama diganti public key-nya saja
+// (-kid 2025-01 -export-public) supaya token lama tetap bisa diverifikasi.
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	alg := flag.String("alg", "RS256", "algoritma: RS256 atau EdDSA")
+	kid := flag.String("kid", "", "key id (nama file <kid>.pem)")
+	out := flag.String("out", "keys", "folder key set")
+	exportPublic := flag.Bool("export-public", false, "ganti <kid>.pem yang ada dengan public key-nya saja")
+	flag.Parse()
+
+	if *kid == "" {
+		log.Fatal("-kid wajib diisi")
+	}
+	path := filepath.Join(*out, *kid+".pem")
+
+	if *exportPublic {
+		if err := exportPublicKey(path); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("public key ditulis ke", path)
+		return
+	}
+
+	var key crypto.Signer
+	var err error
+	switch *alg {
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		log.Fatalf("alg %q tidak didukung", *alg)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(*out, 0o700); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		log.Fatalf("%s sudah ada", path)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("key ditulis ke", path)
+}
+
+func exportPublicKey(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return fmt.Errorf("%s bukan private key PKCS8", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.(crypto.Signer).Public())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644)
+}
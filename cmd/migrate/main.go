@@ -0,0 +1,111 @@
+// migrate menjalankan migrasi skema Postgres yang di-embed di database/migrations.
+//
+//	go run ./cmd/migrate up            # jalankan semua migrasi yang belum diterapkan
+//	go run ./cmd/migrate down [n]      # batalkan n migrasi terakhir (default 1)
+//	go run ./cmd/migrate status        # daftar migrasi dan status penerapannya
+//	go run ./cmd/migrate new <nama>    # buat pasangan file migrasi baru
+//
+// Koneksi dibaca dari config yang sama dengan server (-config, .env, DB_DSN).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"go_clean/config"
+	"go_clean/database"
+	"go_clean/database/migrations"
+)
+
+func main() {
+	dir := flag.String("dir", "database/migrations", "folder sumber migrasi (untuk perintah new)")
+	cfgFlags := config.BindFlags(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "pemakaian: migrate [-dir folder] up | down [n] | status | new <nama>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if args[0] == "new" {
+		if len(args) < 2 {
+			log.Fatal("nama migrasi wajib diisi: migrate new <nama>")
+		}
+		up, down, err := migrations.Create(*dir, args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("dibuat:", up)
+		fmt.Println("dibuat:", down)
+		return
+	}
+
+	cfg, err := config.Load(cfgFlags)
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	m, err := migrations.New(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		ran, err := m.Up(ctx)
+		report("up", ran)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				log.Fatal("jumlah langkah down harus angka positif")
+			}
+		}
+		ran, err := m.Down(ctx, steps)
+		report("down", ran)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, st := range list {
+			applied := "belum"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-40s %s\n", st.Version, st.Name, applied)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func report(direction string, ran []migrations.Migration) {
+	if len(ran) == 0 {
+		fmt.Println("tidak ada migrasi yang dijalankan")
+	}
+	for _, mig := range ran {
+		fmt.Printf("%s %04d_%s\n", direction, mig.Version, mig.Name)
+	}
+}
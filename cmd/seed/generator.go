@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"go_clean/app/models"
+)
+
+// data acak dibuat dari satu *rand.Rand, jadi seed yang sama selalu
+// menghasilkan alumni dan riwayat pekerjaan yang sama persis
+
+type jurusan struct {
+	Nama string
+	Kode string // kode prodi di NIM
+}
+
+var daftarJurusan = []jurusan{
+	{"Teknik Informatika", "411"},
+	{"Sistem Informasi", "412"},
+	{"Teknik Elektro", "311"},
+	{"Teknik Sipil", "321"},
+	{"Manajemen", "511"},
+	{"Akuntansi", "512"},
+	{"Ilmu Komunikasi", "611"},
+	{"Hukum", "711"},
+}
+
+var (
+	namaDepan = []string{
+		"Budi", "Siti", "Agus", "Dewi", "Rizky", "Putri", "Andi", "Ayu", "Fajar", "Nur",
+		"Dimas", "Rina", "Yusuf", "Lestari", "Bayu", "Indah", "Hendra", "Wulan", "Arif", "Maya",
+	}
+	namaBelakang = []string{
+		"Santoso", "Wijaya", "Saputra", "Lestari", "Pratama", "Hidayat", "Nugroho", "Kurniawan",
+		"Setiawan", "Rahmawati", "Siregar", "Harahap", "Simanjuntak", "Permata", "Utami", "Firmansyah",
+	}
+	kota = []string{
+		"Jakarta", "Bandung", "Surabaya", "Yogyakarta", "Semarang", "Medan", "Makassar", "Malang", "Denpasar", "Palembang",
+	}
+	jalan = []string{"Merdeka", "Sudirman", "Diponegoro", "Gajah Mada", "Ahmad Yani", "Pahlawan", "Veteran", "Kenanga"}
+
+	perusahaan = []string{
+		"PT Telkom Indonesia", "PT Bank Mandiri", "Tokopedia", "Gojek", "PT Pertamina", "Bank BCA",
+		"PT Astra International", "Traveloka", "PT Unilever Indonesia", "Bukalapak", "PT PLN", "Kementerian Keuangan",
+	}
+	posisi = []string{
+		"Software Engineer", "Data Analyst", "Staf Keuangan", "Project Manager", "Marketing Executive",
+		"System Analyst", "Auditor", "Legal Officer", "Network Engineer", "HR Officer", "Konsultan",
+	}
+	bidangIndustri = []string{"Teknologi", "Perbankan", "Energi", "Manufaktur", "E-commerce", "Pemerintahan", "Konsultan"}
+	gajiRange      = []string{"3-5 juta", "5-10 juta", "10-15 juta", "15-25 juta", "> 25 juta"}
+)
+
+// seedAlumni adalah satu alumni beserta riwayat pekerjaannya (urut dari yang terlama)
+type seedAlumni struct {
+	Alumni    models.Alumni
+	Pekerjaan []models.PekerjaanAlumni
+}
+
+func pick[T any](rng *rand.Rand, list []T) T {
+	return list[rng.IntN(len(list))]
+}
+
+// generate membuat n alumni tersebar di angkatan fromYear..toYear dan semua jurusan
+func generate(rng *rand.Rand, n, fromYear, toYear int) []seedAlumni {
+	out := make([]seedAlumni, 0, n)
+	// nomor urut NIM per angkatan+jurusan supaya NIM unik
+	urut := map[string]int{}
+
+	for i := 0; i < n; i++ {
+		j := pick(rng, daftarJurusan)
+		angkatan := fromYear + rng.IntN(toYear-fromYear+1)
+		key := fmt.Sprintf("%d-%s", angkatan, j.Kode)
+		urut[key]++
+
+		depan, belakang := pick(rng, namaDepan), pick(rng, namaBelakang)
+		nim := fmt.Sprintf("%02d%s%04d", angkatan%100, j.Kode, urut[key])
+		telp := fmt.Sprintf("08%02d%08d", 11+rng.IntN(89), rng.IntN(100_000_000))
+		alamat := fmt.Sprintf("Jl. %s No. %d, %s", pick(rng, jalan), 1+rng.IntN(200), pick(rng, kota))
+
+		a := models.Alumni{
+			NIM:        nim,
+			Nama:       depan + " " + belakang,
+			Jurusan:    j.Nama,
+			Angkatan:   angkatan,
+			TahunLulus: angkatan + 4 + rng.IntN(2),
+			Email:      fmt.Sprintf("%s.%s.%s@alumni.kampus.ac.id", strings.ToLower(depan), strings.ToLower(belakang), nim),
+			NoTelepon:  &telp,
+			Alamat:     &alamat,
+		}
+		out = append(out, seedAlumni{Alumni: a, Pekerjaan: generatePekerjaan(rng, a)})
+	}
+	return out
+}
+
+// generatePekerjaan membuat 0–5 pekerjaan berurutan setelah lulus;
+// pekerjaan terakhir bisa masih berjalan (tanpa tanggal selesai)
+func generatePekerjaan(rng *rand.Rand, a models.Alumni) []models.PekerjaanAlumni {
+	count := rng.IntN(6)
+	list := make([]models.PekerjaanAlumni, 0, count)
+	mulai := time.Date(a.TahunLulus, time.Month(1+rng.IntN(12)), 1, 0, 0, 0, 0, time.UTC)
+
+	for k := 0; k < count; k++ {
+		gaji := pick(rng, gajiRange)
+		deskripsi := fmt.Sprintf("Bertanggung jawab sebagai %s", strings.ToLower(pick(rng, posisi)))
+		p := models.PekerjaanAlumni{
+			NamaPerusahaan:     pick(rng, perusahaan),
+			PosisiJabatan:      pick(rng, posisi),
+			BidangIndustri:     pick(rng, bidangIndustri),
+			LokasiKerja:        pick(rng, kota),
+			GajiRange:          &gaji,
+			TanggalMulaiKerja:  mulai,
+			StatusPekerjaan:    "aktif",
+			DeskripsiPekerjaan: &deskripsi,
+		}
+
+		last := k == count-1
+		if !last || rng.IntN(3) == 0 {
+			selesai := mulai.AddDate(1+rng.IntN(3), rng.IntN(12), 0)
+			p.TanggalSelesaiKerja = &selesai
+			p.StatusPekerjaan = "selesai"
+			mulai = selesai.AddDate(0, 1+rng.IntN(4), 0)
+		}
+		list = append(list, p)
+	}
+	return list
+}
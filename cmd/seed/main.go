@@ -0,0 +1,228 @@
+// seed mengisi database development dengan data alumni palsu yang realistis.
+//
+//	go run ./cmd/seed -n 200 -seed 42 -target both -reset
+//
+// Seed yang sama selalu menghasilkan data yang sama (nama, NIM, riwayat kerja).
+// Koneksi dibaca dari DB_DSN / MONGO_URI / MONGO_DB (.env ikut dimuat).
+// Skema Postgres harus sudah dibuat dengan cmd/migrate.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"go_clean/app/models"
+	"go_clean/app/repository"
+	"go_clean/config"
+	"go_clean/database"
+	"go_clean/utils"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type options struct {
+	N         int
+	Seed      uint64
+	Target    string
+	FromYear  int
+	ToYear    int
+	Users     int
+	Password  string
+	AdminUser string
+	Reset     bool
+}
+
+func main() {
+	var opt options
+	flag.IntVar(&opt.N, "n", 100, "jumlah alumni")
+	flag.Uint64Var(&opt.Seed, "seed", 1, "seed random; nilai yang sama menghasilkan data yang sama")
+	flag.StringVar(&opt.Target, "target", "postgres", "postgres, mongo, atau both")
+	flag.IntVar(&opt.FromYear, "from", 2012, "angkatan paling awal")
+	flag.IntVar(&opt.ToYear, "to", 2020, "angkatan paling akhir")
+	flag.IntVar(&opt.Users, "users", 10, "jumlah akun user (role user) yang dihubungkan ke alumni, hanya postgres")
+	flag.StringVar(&opt.Password, "password", "password123", "password untuk semua akun seed")
+	flag.StringVar(&opt.AdminUser, "admin", "admin", "username akun admin seed")
+	flag.BoolVar(&opt.Reset, "reset", false, "hapus data alumni/pekerjaan/user yang ada sebelum seed")
+	cfgFlags := config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	if opt.N < 0 || opt.FromYear > opt.ToYear {
+		log.Fatal("-n tidak boleh negatif dan -from harus <= -to")
+	}
+	pg := opt.Target == "postgres" || opt.Target == "both"
+	mg := opt.Target == "mongo" || opt.Target == "both"
+	if !pg && !mg {
+		log.Fatalf("target %q tidak dikenal (postgres, mongo, both)", opt.Target)
+	}
+
+	rng := rand.New(rand.NewPCG(opt.Seed, opt.Seed))
+	data := generate(rng, opt.N, opt.FromYear, opt.ToYear)
+
+	cfg, err := config.Load(cfgFlags)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// id alumni Postgres dipakai juga sebagai alumni_id di Mongo jika target both
+	ids := make([]int, len(data))
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	if pg {
+		db, err := database.ConnectDB(cfg.Database)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+		if err := seedPostgres(ctx, db, data, ids, opt); err != nil {
+			log.Fatalf("seed postgres gagal: %v", err)
+		}
+	}
+	if mg {
+		client, err := database.ConnectMongoDB(cfg.Mongo)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer client.Disconnect(ctx)
+		if err := seedMongo(ctx, client.Database(cfg.Mongo.Database), data, ids, opt.Reset); err != nil {
+			log.Fatalf("seed mongo gagal: %v", err)
+		}
+	}
+}
+
+func seedPostgres(ctx context.Context, db *sql.DB, data []seedAlumni, ids []int, opt options) error {
+	if opt.Reset {
+		if _, err := db.Exec(`TRUNCATE pekerjaan_alumni, alumni, users RESTART IDENTITY CASCADE`); err != nil {
+			return err
+		}
+	}
+
+	roleRepo := &repository.RoleRepository{DB: db}
+	alumniRepo := &repository.AlumniRepository{DB: db}
+	pekerjaanRepo := &repository.PekerjaanRepository{DB: db}
+	userRepo := &repository.UserRepository{DB: db}
+
+	if err := roleRepo.EnsureBuiltinRoles(ctx); err != nil {
+		return err
+	}
+
+	jobs := 0
+	for i := range data {
+		id, err := alumniRepo.CreateAlumni(ctx, &data[i].Alumni)
+		if err != nil {
+			return fmt.Errorf("alumni %s: %w", data[i].Alumni.NIM, err)
+		}
+		ids[i] = id
+		for _, p := range data[i].Pekerjaan {
+			p.AlumniID = id
+			if _, err := pekerjaanRepo.CreatePekerjaan(ctx, &p); err != nil {
+				return fmt.Errorf("pekerjaan alumni %s: %w", data[i].Alumni.NIM, err)
+			}
+			jobs++
+		}
+	}
+
+	hash, err := utils.HashPassword(opt.Password)
+	if err != nil {
+		return err
+	}
+	admin, err := userRepo.Create(ctx, opt.AdminUser, opt.AdminUser+"@kampus.ac.id", hash, "admin")
+	if err != nil {
+		return fmt.Errorf("akun admin: %w", err)
+	}
+	if _, err := userRepo.MarkEmailVerified(ctx, admin.ID, admin.Email); err != nil {
+		return err
+	}
+
+	users := 0
+	for i := 0; i < opt.Users && i < len(data); i++ {
+		a := data[i].Alumni
+		u, err := userRepo.Create(ctx, a.NIM, a.Email, hash, "user")
+		if err != nil {
+			return fmt.Errorf("akun user %s: %w", a.NIM, err)
+		}
+		if _, err := userRepo.SetAlumniID(ctx, u.ID, &ids[i]); err != nil {
+			return err
+		}
+		if _, err := userRepo.MarkEmailVerified(ctx, u.ID, u.Email); err != nil {
+			return err
+		}
+		users++
+	}
+
+	log.Printf("postgres: %d alumni, %d pekerjaan, 1 admin (%s), %d user (username = NIM), password %q",
+		len(data), jobs, opt.AdminUser, users, opt.Password)
+	return nil
+}
+
+func seedMongo(ctx context.Context, db *mongo.Database, data []seedAlumni, ids []int, reset bool) error {
+	alumniCol, pekerjaanCol := db.Collection("alumni"), db.Collection("pekerjaan")
+	if reset {
+		if err := alumniCol.Drop(ctx); err != nil {
+			return err
+		}
+		if err := pekerjaanCol.Drop(ctx); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	var alumniDocs, pekerjaanDocs []interface{}
+	for i, d := range data {
+		a := d.Alumni
+		doc := models.AlumniMongo{
+			AlumniID:   ids[i],
+			NIM:        a.NIM,
+			Nama:       a.Nama,
+			Jurusan:    a.Jurusan,
+			Angkatan:   a.Angkatan,
+			TahunLulus: a.TahunLulus,
+			Email:      a.Email,
+			NoTelp:     *a.NoTelepon,
+			Alamat:     *a.Alamat,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		for _, p := range d.Pekerjaan {
+			mulai := p.TanggalMulaiKerja
+			pekerjaanDocs = append(pekerjaanDocs, models.PekerjaanMongo{
+				AlumniID:            ids[i],
+				NamaPerusahaan:      p.NamaPerusahaan,
+				PosisiJabatan:       p.PosisiJabatan,
+				BidangIndustri:      p.BidangIndustri,
+				LokasiKerja:         p.LokasiKerja,
+				GajiRange:           p.GajiRange,
+				TanggalMulaiKerja:   &mulai,
+				TanggalSelesaiKerja: p.TanggalSelesaiKerja,
+				StatusPekerjaan:     p.StatusPekerjaan,
+				DeskripsiPekerjaan:  p.DeskripsiPekerjaan,
+				CreatedAt:           now,
+				UpdatedAt:           now,
+			})
+			if p.TanggalSelesaiKerja == nil {
+				doc.TempatKerja = p.NamaPerusahaan
+			}
+		}
+		alumniDocs = append(alumniDocs, doc)
+	}
+
+	if len(alumniDocs) > 0 {
+		if _, err := alumniCol.InsertMany(ctx, alumniDocs); err != nil {
+			return err
+		}
+	}
+	if len(pekerjaanDocs) > 0 {
+		if _, err := pekerjaanCol.InsertMany(ctx, pekerjaanDocs); err != nil {
+			return err
+		}
+	}
+	log.Printf("mongo: %d alumni, %d pekerjaan", len(alumniDocs), len(pekerjaanDocs))
+	return nil
+}
@@ -0,0 +1,126 @@
+package config
+
+import "time"
+
+type AuthConfig struct {
+	ResetTokenTTL  time.Duration  `yaml:"reset_token_ttl" toml:"reset_token_ttl"`
+	PasswordPolicy PasswordPolicy `yaml:"password_policy" toml:"password_policy"`
+
+	// brute-force protection pada /api/login
+	MaxAccountFailures int           `yaml:"max_account_failures" toml:"max_account_failures"`
+	MaxIPFailures      int           `yaml:"max_ip_failures" toml:"max_ip_failures"`
+	LockoutBase        time.Duration `yaml:"lockout_base" toml:"lockout_base"` // durasi lock pertama, berlipat dua tiap lock berikutnya
+	LockoutMax         time.Duration `yaml:"lockout_max" toml:"lockout_max"`
+	// gagal login yang lebih lama dari ini tidak dihitung lagi; counter per IP
+	// hanya turun lewat window ini, tidak di-reset oleh login sukses
+	FailureWindow time.Duration `yaml:"failure_window" toml:"failure_window"`
+
+	// TOTP 2FA
+	MFAIssuer       string        `yaml:"mfa_issuer" toml:"mfa_issuer"`
+	MFAChallengeTTL time.Duration `yaml:"mfa_challenge_ttl" toml:"mfa_challenge_ttl"`
+
+	// kode verifikasi klaim akun alumni; tiap user maksimal ClaimMaxPerWindow
+	// klaim baru dalam ClaimWindow
+	ClaimCodeTTL      time.Duration `yaml:"claim_code_ttl" toml:"claim_code_ttl"`
+	ClaimMaxPerWindow int           `yaml:"claim_max_per_window" toml:"claim_max_per_window"`
+	ClaimWindow       time.Duration `yaml:"claim_window" toml:"claim_window"`
+
+	// API key integrasi antar sistem
+	APIKeyDefaultTTL time.Duration `yaml:"api_key_default_ttl" toml:"api_key_default_ttl"`
+	APIKeyMaxTTL     time.Duration `yaml:"api_key_max_ttl" toml:"api_key_max_ttl"`
+
+	// masa berlaku token impersonasi admin (tanpa refresh token)
+	ImpersonationTTL time.Duration `yaml:"impersonation_ttl" toml:"impersonation_ttl"`
+
+	// verifikasi email akun baru
+	EmailVerifyTTL            time.Duration `yaml:"email_verify_ttl" toml:"email_verify_ttl"`
+	EmailVerifyResendInterval time.Duration `yaml:"email_verify_resend_interval" toml:"email_verify_resend_interval"`
+}
+
+// PasswordPolicy adalah aturan minimal password baru (register, reset, ganti password)
+type PasswordPolicy struct {
+	MinLength     int  `yaml:"min_length" toml:"min_length"`
+	RequireUpper  bool `yaml:"require_upper" toml:"require_upper"`
+	RequireLower  bool `yaml:"require_lower" toml:"require_lower"`
+	RequireDigit  bool `yaml:"require_digit" toml:"require_digit"`
+	RequireSymbol bool `yaml:"require_symbol" toml:"require_symbol"`
+}
+
+func defaultAuth() AuthConfig {
+	return AuthConfig{
+		ResetTokenTTL: 30 * time.Minute,
+		PasswordPolicy: PasswordPolicy{
+			MinLength:    8,
+			RequireDigit: true,
+		},
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		LockoutBase:        time.Minute,
+		LockoutMax:         time.Hour,
+		FailureWindow:      15 * time.Minute,
+		MFAIssuer:          "Alumni API",
+		MFAChallengeTTL:    5 * time.Minute,
+		ClaimCodeTTL:       15 * time.Minute,
+		ClaimMaxPerWindow:  5,
+		ClaimWindow:        24 * time.Hour,
+		APIKeyDefaultTTL:   90 * 24 * time.Hour,
+		APIKeyMaxTTL:       365 * 24 * time.Hour,
+		ImpersonationTTL:   15 * time.Minute,
+		EmailVerifyTTL:     24 * time.Hour,
+		// jeda minimal antar kirim ulang link verifikasi
+		EmailVerifyResendInterval: time.Minute,
+	}
+}
+
+func (c *AuthConfig) applyEnv(e *envReader) {
+	e.duration("PASSWORD_RESET_TTL_MINUTES", time.Minute, &c.ResetTokenTTL)
+	e.int("PASSWORD_MIN_LENGTH", &c.PasswordPolicy.MinLength)
+	e.bool("PASSWORD_REQUIRE_UPPER", &c.PasswordPolicy.RequireUpper)
+	e.bool("PASSWORD_REQUIRE_LOWER", &c.PasswordPolicy.RequireLower)
+	e.bool("PASSWORD_REQUIRE_DIGIT", &c.PasswordPolicy.RequireDigit)
+	e.bool("PASSWORD_REQUIRE_SYMBOL", &c.PasswordPolicy.RequireSymbol)
+	e.int("LOGIN_MAX_FAILURES", &c.MaxAccountFailures)
+	e.int("LOGIN_MAX_FAILURES_PER_IP", &c.MaxIPFailures)
+	e.duration("LOGIN_LOCKOUT_BASE_SECONDS", time.Second, &c.LockoutBase)
+	e.duration("LOGIN_LOCKOUT_MAX_SECONDS", time.Second, &c.LockoutMax)
+	e.duration("LOGIN_FAILURE_WINDOW_SECONDS", time.Second, &c.FailureWindow)
+	e.str("MFA_ISSUER", &c.MFAIssuer)
+	e.duration("MFA_CHALLENGE_TTL_MINUTES", time.Minute, &c.MFAChallengeTTL)
+	e.duration("CLAIM_CODE_TTL_MINUTES", time.Minute, &c.ClaimCodeTTL)
+	e.int("CLAIM_MAX_PER_WINDOW", &c.ClaimMaxPerWindow)
+	e.duration("CLAIM_WINDOW_HOURS", time.Hour, &c.ClaimWindow)
+	e.duration("API_KEY_DEFAULT_TTL_DAYS", 24*time.Hour, &c.APIKeyDefaultTTL)
+	e.duration("API_KEY_MAX_TTL_DAYS", 24*time.Hour, &c.APIKeyMaxTTL)
+	e.duration("IMPERSONATION_TTL_MINUTES", time.Minute, &c.ImpersonationTTL)
+	e.duration("EMAIL_VERIFY_TTL_HOURS", time.Hour, &c.EmailVerifyTTL)
+	e.duration("EMAIL_VERIFY_RESEND_SECONDS", time.Second, &c.EmailVerifyResendInterval)
+}
+
+func (c AuthConfig) validate(v *validator) {
+	v.positive("auth.reset_token_ttl", c.ResetTokenTTL)
+	if c.PasswordPolicy.MinLength < 1 {
+		v.add("auth.password_policy.min_length harus minimal 1")
+	}
+	if c.MaxAccountFailures < 1 || c.MaxIPFailures < 1 {
+		v.add("auth.max_account_failures dan auth.max_ip_failures harus minimal 1")
+	}
+	v.positive("auth.lockout_base", c.LockoutBase)
+	if c.LockoutMax < c.LockoutBase {
+		v.add("auth.lockout_max tidak boleh lebih kecil dari auth.lockout_base")
+	}
+	v.positive("auth.failure_window", c.FailureWindow)
+	v.required("auth.mfa_issuer", c.MFAIssuer)
+	v.positive("auth.mfa_challenge_ttl", c.MFAChallengeTTL)
+	v.positive("auth.claim_code_ttl", c.ClaimCodeTTL)
+	if c.ClaimMaxPerWindow < 1 {
+		v.add("auth.claim_max_per_window harus minimal 1")
+	}
+	v.positive("auth.claim_window", c.ClaimWindow)
+	v.positive("auth.api_key_default_ttl", c.APIKeyDefaultTTL)
+	if c.APIKeyMaxTTL < c.APIKeyDefaultTTL {
+		v.add("auth.api_key_max_ttl tidak boleh lebih kecil dari auth.api_key_default_ttl")
+	}
+	v.positive("auth.impersonation_ttl", c.ImpersonationTTL)
+	v.positive("auth.email_verify_ttl", c.EmailVerifyTTL)
+	v.positive("auth.email_verify_resend_interval", c.EmailVerifyResendInterval)
+}
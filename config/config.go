@@ -0,0 +1,311 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config adalah seluruh konfigurasi aplikasi. Dimuat sekali saat startup oleh
+// Load lalu diteruskan ke constructor yang membutuhkan; tidak ada paket lain
+// yang membaca os.Getenv sendiri.
+//
+// Urutan lapisan (yang belakang menimpa yang depan):
+//  1. default di kode
+//  2. file YAML/TOML opsional (-config atau CONFIG_FILE)
+//  3. environment (termasuk .env jika ada)
+//  4. flag command line
+type Config struct {
+	App      AppConfig      `yaml:"app" toml:"app"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	Mongo    MongoConfig    `yaml:"mongo" toml:"mongo"`
+	JWT      JWTConfig      `yaml:"jwt" toml:"jwt"`
+	Auth     AuthConfig     `yaml:"auth" toml:"auth"`
+	Mail     MailConfig     `yaml:"mail" toml:"mail"`
+	OIDC     OIDCConfig     `yaml:"oidc" toml:"oidc"`
+}
+
+type AppConfig struct {
+	Env  string `yaml:"env" toml:"env"` // "production" mematikan request logger
+	Port int    `yaml:"port" toml:"port"`
+
+	// batas waktu tiap request (termasuk query database); lewat dari ini → 504
+	RequestTimeout time.Duration `yaml:"request_timeout" toml:"request_timeout"`
+	// override per route, key "METHOD /prefix" atau "/prefix" (semua method),
+	// mis. "GET /api/alumni/alumni-pag": 3s. Prefix terpanjang yang menang.
+	RouteTimeouts map[string]time.Duration `yaml:"route_timeouts" toml:"route_timeouts"`
+}
+
+type DatabaseConfig struct {
+	DSN string `yaml:"dsn" toml:"dsn"`
+}
+
+type MongoConfig struct {
+	URI      string `yaml:"uri" toml:"uri"`
+	Database string `yaml:"database" toml:"database"`
+}
+
+// Default mengembalikan config dengan nilai bawaan; DSN dan pengaturan JWT
+// sengaja kosong karena wajib diisi
+func Default() Config {
+	return Config{
+		App:   AppConfig{Env: "development", Port: 8080, RequestTimeout: 10 * time.Second},
+		Mongo: MongoConfig{URI: "mongodb://localhost:27017", Database: "alumni_db"},
+		JWT:   defaultJWT(),
+		Auth:  defaultAuth(),
+		Mail:  defaultMail(),
+		OIDC:  defaultOIDC(),
+	}
+}
+
+func (c *AppConfig) applyEnv(e *envReader) {
+	e.str("APP_ENV", &c.Env)
+	e.int("APP_PORT", &c.Port)
+	e.duration("REQUEST_TIMEOUT_SECONDS", time.Second, &c.RequestTimeout)
+	e.durationMap("ROUTE_TIMEOUTS", &c.RouteTimeouts)
+}
+
+func (c *DatabaseConfig) applyEnv(e *envReader) {
+	e.str("DB_DSN", &c.DSN)
+}
+
+func (c *MongoConfig) applyEnv(e *envReader) {
+	e.str("MONGO_URI", &c.URI)
+	e.str("MONGO_DB", &c.Database)
+}
+
+func (c *Config) applyEnv(e *envReader) {
+	c.App.applyEnv(e)
+	c.Database.applyEnv(e)
+	c.Mongo.applyEnv(e)
+	c.JWT.applyEnv(e)
+	c.Auth.applyEnv(e)
+	c.Mail.applyEnv(e)
+	c.OIDC.applyEnv(e)
+}
+
+// Flags menampung override dari command line. Flag bernama dipetakan ke key
+// env yang sama; -set KEY=VALUE bisa menimpa key env apa pun.
+type Flags struct {
+	File   string
+	values map[string]*string
+	set    setFlag
+}
+
+// flag bernama → key env yang ditimpanya
+var namedFlags = []struct{ name, key, usage string }{
+	{"port", "APP_PORT", "port HTTP server"},
+	{"env", "APP_ENV", "lingkungan aplikasi (development, production)"},
+	{"db-dsn", "DB_DSN", "DSN PostgreSQL"},
+	{"mongo-uri", "MONGO_URI", "URI MongoDB"},
+	{"mongo-db", "MONGO_DB", "nama database MongoDB"},
+	{"jwt-keys-dir", "JWT_KEYS_DIR", "folder key JWT"},
+	{"jwt-active-kid", "JWT_ACTIVE_KID", "kid key JWT yang aktif"},
+}
+
+// BindFlags mendaftarkan flag config ke fs. Panggil sebelum fs.Parse, lalu
+// teruskan hasilnya ke Load.
+func BindFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{values: map[string]*string{}}
+	fs.StringVar(&f.File, "config", "", "file config YAML/TOML (default: env CONFIG_FILE)")
+	for _, nf := range namedFlags {
+		f.values[nf.key] = fs.String(nf.name, "", nf.usage+" (menimpa "+nf.key+")")
+	}
+	fs.Var(&f.set, "set", "menimpa key config apa pun, format KEY=VALUE (boleh diulang)")
+	return f
+}
+
+func (f *Flags) lookup(key string) (string, bool) {
+	if v, ok := f.set[key]; ok {
+		return v, v != ""
+	}
+	if p := f.values[key]; p != nil && *p != "" {
+		return *p, true
+	}
+	return "", false
+}
+
+type setFlag map[string]string
+
+func (s *setFlag) String() string { return "" }
+
+func (s *setFlag) Set(v string) error {
+	key, val, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("format harus KEY=VALUE")
+	}
+	if *s == nil {
+		*s = setFlag{}
+	}
+	(*s)[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	return nil
+}
+
+// Load menyusun Config dari semua lapisan dan memvalidasinya. Semua kesalahan
+// (nilai env tidak valid, field wajib kosong, dst.) dikembalikan sekaligus
+// sebagai *ValidationError. f boleh nil untuk program tanpa flag config.
+func Load(f *Flags) (Config, error) {
+	if err := LoadEnv(); err != nil {
+		return Config{}, err
+	}
+	cfg := Default()
+
+	path := os.Getenv("CONFIG_FILE")
+	if f != nil && f.File != "" {
+		path = f.File
+	}
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	env := &envReader{lookup: lookupEnv}
+	cfg.applyEnv(env)
+	problems := env.errs
+	if f != nil {
+		fl := &envReader{lookup: f.lookup}
+		cfg.applyEnv(fl)
+		for _, e := range fl.errs {
+			problems = append(problems, "flag "+e)
+		}
+	}
+
+	cfg.Mail.normalize()
+	cfg.OIDC.normalize()
+
+	v := &validator{problems: problems}
+	cfg.validate(v)
+	if len(v.problems) > 0 {
+		return cfg, &ValidationError{Problems: v.problems}
+	}
+	return cfg, nil
+}
+
+// loadFile membaca file config; formatnya ditentukan dari ekstensi
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("membaca file config: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true) // salah ketik nama field langsung ketahuan
+		if err := dec.Decode(cfg); err != nil {
+			return fmt.Errorf("file config %s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("file config %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("file config %s: field tidak dikenal: %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("file config %s: ekstensi harus .yaml, .yml, atau .toml", path)
+	}
+	return nil
+}
+
+// ValidationError berisi semua masalah config yang ditemukan saat Load
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "konfigurasi tidak valid:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add("%s wajib diisi", name)
+	}
+}
+
+func (v *validator) positive(name string, d time.Duration) {
+	if d <= 0 {
+		v.add("%s harus lebih dari 0", name)
+	}
+}
+
+func (v *validator) url(name, value string) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("%s harus URL http(s) yang valid", name)
+	}
+}
+
+func (c Config) validate(v *validator) {
+	if c.App.Port < 1 || c.App.Port > 65535 {
+		v.add("app.port (APP_PORT) harus 1-65535")
+	}
+	v.positive("app.request_timeout (REQUEST_TIMEOUT_SECONDS)", c.App.RequestTimeout)
+	for route, d := range c.App.RouteTimeouts {
+		v.positive(fmt.Sprintf("app.route_timeouts[%q]", route), d)
+	}
+	v.required("database.dsn (DB_DSN)", c.Database.DSN)
+	v.required("mongo.uri (MONGO_URI)", c.Mongo.URI)
+	v.required("mongo.database (MONGO_DB)", c.Mongo.Database)
+	c.JWT.validate(v)
+	c.Auth.validate(v)
+	c.Mail.validate(v)
+	c.OIDC.validate(v)
+}
+
+// sama dengan penanda yang dipakai url.URL.Redacted
+const redacted = "xxxxx"
+
+// password=... pada DSN format key=value milik lib/pq
+var dsnPassword = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Redacted mengembalikan salinan config dengan semua rahasia disamarkan,
+// aman untuk dicetak ke log
+func (c Config) Redacted() Config {
+	c.Database.DSN = redactDSN(c.Database.DSN)
+	c.Mongo.URI = redactDSN(c.Mongo.URI)
+	if c.Mail.SMTPPassword != "" {
+		c.Mail.SMTPPassword = redacted
+	}
+	if c.OIDC.ClientSecret != "" {
+		c.OIDC.ClientSecret = redacted
+	}
+	c.OIDC.Scopes = append([]string(nil), c.OIDC.Scopes...)
+	c.App.RouteTimeouts = maps.Clone(c.App.RouteTimeouts)
+	return c
+}
+
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Redacted()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
+
+// String mencetak config (sudah diredaksi) dalam format YAML
+func (c Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return "config: " + err.Error()
+	}
+	return string(out)
+}
@@ -1,15 +1,123 @@
 package config
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-// LoadEnv memuat variabel lingkungan dari file .env
-func LoadEnv() {
+// LoadEnv memuat variabel lingkungan dari file .env jika ada. File yang tidak
+// ada bukan error: di container variabel biasanya di-inject langsung.
+// Variabel yang sudah diset di environment tidak ditimpa.
+func LoadEnv() error {
 	err := godotenv.Load()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("membaca .env: %w", err)
+	}
+	return nil
+}
+
+// lookupFunc mengembalikan nilai mentah untuk sebuah key env (mis. "APP_PORT")
+type lookupFunc func(key string) (string, bool)
+
+func lookupEnv(key string) (string, bool) {
+	v, ok := os.LookupEnv(key)
+	v = strings.TrimSpace(v)
+	return v, ok && v != ""
+}
+
+// envReader menimpa field config dengan nilai dari sebuah sumber (env atau flag).
+// Key yang tidak diset dibiarkan; nilai yang tidak valid dicatat sebagai error
+// supaya semua kesalahan bisa dilaporkan sekaligus.
+type envReader struct {
+	lookup lookupFunc
+	errs   []string
+}
+
+func (e *envReader) fail(key, format string, args ...interface{}) {
+	e.errs = append(e.errs, key+": "+fmt.Sprintf(format, args...))
+}
+
+func (e *envReader) str(key string, dst *string) {
+	if v, ok := e.lookup(key); ok {
+		*dst = v
+	}
+}
+
+func (e *envReader) int(key string, dst *int) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(v)
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		e.fail(key, "%q bukan angka", v)
+		return
+	}
+	*dst = n
+}
+
+func (e *envReader) bool(key string, dst *bool) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.fail(key, "%q bukan true/false", v)
+		return
+	}
+	*dst = b
+}
+
+// duration membaca angka dalam satuan unit (mis. JWT_ACCESS_TTL_MINUTES=15),
+// atau durasi Go lengkap seperti "90s"
+func (e *envReader) duration(key string, unit time.Duration, dst *time.Duration) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		*dst = time.Duration(n) * unit
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.fail(key, "%q bukan durasi", v)
+		return
+	}
+	*dst = d
+}
+
+// durationMap membaca pasangan "kunci=durasi" dipisah koma, mis.
+// ROUTE_TIMEOUTS="GET /api/alumni/alumni-pag=3s, /api/pekerjaan-mongo=8s"
+func (e *envReader) durationMap(key string, dst *map[string]time.Duration) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	m := map[string]time.Duration{}
+	for _, pair := range strings.Split(v, ",") {
+		k, raw, found := strings.Cut(strings.TrimSpace(pair), "=")
+		d, err := time.ParseDuration(strings.TrimSpace(raw))
+		if !found || strings.TrimSpace(k) == "" || err != nil {
+			e.fail(key, "%q harus berformat kunci=durasi", pair)
+			return
+		}
+		m[strings.TrimSpace(k)] = d
+	}
+	*dst = m
+}
+
+// fields membaca daftar yang dipisah spasi, mis. OIDC_SCOPES="openid email"
+func (e *envReader) fields(key string, dst *[]string) {
+	if v, ok := e.lookup(key); ok {
+		*dst = strings.Fields(v)
 	}
-}
\ No newline at end of file
+}
@@ -1,25 +1,41 @@
 package config
 
-import (
-	"log"
-	"os"
-	"strconv"
-)
+import "time"
 
 type JWTConfig struct {
-	Secret []byte
-	TTLHours int
+	KeysDir    string        `yaml:"keys_dir" toml:"keys_dir"`     // folder berisi <kid>.pem (private key untuk signing, public key untuk verifikasi saja)
+	ActiveKID  string        `yaml:"active_kid" toml:"active_kid"` // kid dari key yang dipakai menandatangani token baru
+	Issuer     string        `yaml:"issuer" toml:"issuer"`
+	Audience   string        `yaml:"audience" toml:"audience"`
+	AccessTTL  time.Duration `yaml:"access_ttl" toml:"access_ttl"`
+	RefreshTTL time.Duration `yaml:"refresh_ttl" toml:"refresh_ttl"`
 }
 
-func LoadJWT() JWTConfig {
-	secret := os.Getenv("JWT_SECRET")
-	if len(secret) < 32 {
-		log.Fatal("JWT_SECRET minimal 32 karakter")
+func defaultJWT() JWTConfig {
+	return JWTConfig{
+		KeysDir: "keys",
+		// access token dibuat pendek, sesi panjang dipegang oleh refresh token
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 30 * 24 * time.Hour,
 	}
-	ttlStr := os.Getenv("JWT_TTL_HOURS")
-	ttl, err := strconv.Atoi(ttlStr)
-	if err != nil || ttl <= 0 {
-		ttl = 24
+}
+
+func (c *JWTConfig) applyEnv(e *envReader) {
+	e.str("JWT_KEYS_DIR", &c.KeysDir)
+	e.str("JWT_ACTIVE_KID", &c.ActiveKID)
+	e.str("JWT_ISSUER", &c.Issuer)
+	e.str("JWT_AUDIENCE", &c.Audience)
+	e.duration("JWT_ACCESS_TTL_MINUTES", time.Minute, &c.AccessTTL)
+	e.duration("JWT_REFRESH_TTL_HOURS", time.Hour, &c.RefreshTTL)
+}
+
+func (c JWTConfig) validate(v *validator) {
+	v.required("jwt.keys_dir (JWT_KEYS_DIR)", c.KeysDir)
+	v.required("jwt.active_kid (JWT_ACTIVE_KID)", c.ActiveKID)
+	v.required("jwt.issuer (JWT_ISSUER)", c.Issuer)
+	v.required("jwt.audience (JWT_AUDIENCE)", c.Audience)
+	v.positive("jwt.access_ttl", c.AccessTTL)
+	if c.RefreshTTL <= c.AccessTTL {
+		v.add("jwt.refresh_ttl harus lebih panjang dari jwt.access_ttl")
 	}
-	return JWTConfig{Secret: []byte(secret), TTLHours: ttl}
 }
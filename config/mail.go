@@ -0,0 +1,53 @@
+package config
+
+import "strings"
+
+type MailConfig struct {
+	Driver       string `yaml:"driver" toml:"driver"` // "smtp" atau "log"
+	SMTPHost     string `yaml:"smtp_host" toml:"smtp_host"`
+	SMTPPort     string `yaml:"smtp_port" toml:"smtp_port"`
+	SMTPUsername string `yaml:"smtp_username" toml:"smtp_username"`
+	SMTPPassword string `yaml:"smtp_password" toml:"smtp_password"`
+	From         string `yaml:"from" toml:"from"`
+	LogFile      string `yaml:"log_file" toml:"log_file"` // dipakai driver "log"; kosong = tulis ke log server
+	BaseURL      string `yaml:"base_url" toml:"base_url"` // URL frontend untuk link di email
+}
+
+func defaultMail() MailConfig {
+	return MailConfig{
+		Driver:   "log",
+		SMTPPort: "587",
+		From:     "no-reply@localhost",
+		BaseURL:  "http://localhost:3000",
+	}
+}
+
+func (c *MailConfig) applyEnv(e *envReader) {
+	e.str("MAIL_DRIVER", &c.Driver)
+	e.str("SMTP_HOST", &c.SMTPHost)
+	e.str("SMTP_PORT", &c.SMTPPort)
+	e.str("SMTP_USERNAME", &c.SMTPUsername)
+	e.str("SMTP_PASSWORD", &c.SMTPPassword)
+	e.str("MAIL_FROM", &c.From)
+	e.str("MAIL_LOG_FILE", &c.LogFile)
+	e.str("APP_BASE_URL", &c.BaseURL)
+}
+
+// normalize dipanggil setelah semua lapisan diterapkan
+func (c *MailConfig) normalize() {
+	c.Driver = strings.ToLower(c.Driver)
+	c.BaseURL = strings.TrimRight(c.BaseURL, "/")
+}
+
+func (c MailConfig) validate(v *validator) {
+	switch c.Driver {
+	case "log":
+	case "smtp":
+		v.required("mail.smtp_host (SMTP_HOST)", c.SMTPHost)
+		v.required("mail.smtp_port (SMTP_PORT)", c.SMTPPort)
+	default:
+		v.add("mail.driver (MAIL_DRIVER) harus \"smtp\" atau \"log\", bukan %q", c.Driver)
+	}
+	v.required("mail.from (MAIL_FROM)", c.From)
+	v.url("mail.base_url (APP_BASE_URL)", c.BaseURL)
+}
@@ -0,0 +1,161 @@
+// Package container merakit seluruh dependency aplikasi (koneksi database,
+// repository, service) secara eksplisit. Tidak ada state global: beberapa
+// Container bisa hidup berdampingan, mis. di satu proses test.
+package container
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go_clean/app/policy"
+	"go_clean/app/repository"
+	"go_clean/app/service"
+	"go_clean/config"
+	"go_clean/database"
+	"go_clean/mailer"
+	"go_clean/oidc"
+	"go_clean/utils"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Container struct {
+	Config config.Config
+
+	DB      *sql.DB
+	Mongo   *mongo.Client // nil jika Container dibuat lewat Build
+	MongoDB *mongo.Database
+	JWT     *utils.JWT
+	Mailer  mailer.Mailer
+	// UoW menjalankan beberapa panggilan repository Postgres dalam satu transaksi
+	UoW *repository.UnitOfWork
+
+	Repos    Repositories
+	Services Services
+
+	// true jika koneksi dibuka oleh New (dan karenanya ditutup oleh Close)
+	ownsConns bool
+}
+
+// Repositories berisi repository Postgres (tanpa transaksi) ditambah repository Mongo
+type Repositories struct {
+	repository.Repositories
+	AlumniMongo    *repository.AlumniMongoRepository
+	PekerjaanMongo *repository.PekerjaanMongoRepository
+}
+
+type Services struct {
+	Alumni         *service.AlumniService
+	Pekerjaan      *service.PekerjaanService
+	Authz          *policy.Authorizer
+	Tokens         *service.TokenIssuer
+	Passwords      *service.PasswordService
+	Verification   *service.EmailVerificationService
+	Users          *service.UserService
+	Roles          *service.RoleService
+	Me             *service.MeService
+	Claims         *service.ClaimService
+	Lockout        *service.LockoutService
+	MFA            *service.MFAService
+	OIDC           *service.OIDCService
+	APIKeys        *service.APIKeyService
+	Auth           *service.AuthService
+	AlumniMongo    *service.AlumniMongoService
+	PekerjaanMongo *service.PekerjaanMongoService
+}
+
+// New membuka koneksi Postgres dan MongoDB, memuat key JWT, lalu merakit
+// repository dan service. Panggil Close saat aplikasi berhenti.
+func New(ctx context.Context, cfg config.Config) (*Container, error) {
+	jwt, err := utils.NewJWT(cfg.JWT)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat key JWT: %w", err)
+	}
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	client, err := database.ConnectMongoDB(cfg.Mongo)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	c := Build(cfg, db, client.Database(cfg.Mongo.Database), jwt)
+	c.Mongo, c.ownsConns = client, true
+	if err := c.Repos.Roles.EnsureBuiltinRoles(ctx); err != nil {
+		c.Close(context.Background())
+		return nil, fmt.Errorf("gagal menyiapkan role bawaan: %w", err)
+	}
+	return c, nil
+}
+
+// Build merakit repository dan service di atas koneksi yang sudah ada.
+// mongoDB boleh nil (route Mongo tidak dipasang). Koneksi tetap milik
+// pemanggil: Close pada Container hasil Build tidak menutupnya.
+func Build(cfg config.Config, db *sql.DB, mongoDB *mongo.Database, jwt *utils.JWT) *Container {
+	c := &Container{Config: cfg, DB: db, MongoDB: mongoDB, JWT: jwt, Mailer: mailer.New(cfg.Mail)}
+
+	c.UoW = &repository.UnitOfWork{DB: db}
+	r := Repositories{Repositories: repository.NewRepositories(db)}
+	if mongoDB != nil {
+		r.AlumniMongo = repository.NewAlumniMongoRepository(mongoDB)
+		r.PekerjaanMongo = repository.NewPekerjaanMongoRepository(mongoDB)
+	}
+	c.Repos = r
+
+	var s Services
+	s.Alumni = &service.AlumniService{Repo: r.Alumni, Tx: c.UoW}
+	s.Authz = &policy.Authorizer{Users: r.Users}
+	s.Pekerjaan = &service.PekerjaanService{Repo: r.Pekerjaan, Tx: c.UoW}
+	s.Tokens = &service.TokenIssuer{Sessions: r.Sessions, Roles: r.Roles, Auth: cfg.Auth, JWT: jwt}
+	s.Passwords = &service.PasswordService{
+		Users: r.Users, Resets: r.Resets, Sessions: r.Sessions, Mailer: c.Mailer,
+		Auth: cfg.Auth, BaseURL: cfg.Mail.BaseURL, Tx: c.UoW,
+	}
+	s.Verification = &service.EmailVerificationService{
+		Users: r.Users, Mailer: c.Mailer, Auth: cfg.Auth, JWT: jwt, BaseURL: cfg.Mail.BaseURL,
+	}
+	s.Users = &service.UserService{
+		Repo: r.Users, Roles: r.Roles, Alumni: r.Alumni, Sessions: r.Sessions,
+		Tokens: s.Tokens, Passwords: s.Passwords, Verification: s.Verification, Tx: c.UoW,
+	}
+	s.Roles = &service.RoleService{Repo: r.Roles, Tx: c.UoW}
+	s.Me = &service.MeService{
+		Users: r.Users, Alumni: r.Alumni, Pekerjaan: r.Pekerjaan, Sessions: r.Sessions,
+		Verification: s.Verification, Passwords: s.Passwords, Tx: c.UoW,
+	}
+	s.Claims = &service.ClaimService{Claims: r.Claims, Users: r.Users, Alumni: r.Alumni, Mailer: c.Mailer, Auth: cfg.Auth, Tx: c.UoW}
+	s.Lockout = &service.LockoutService{Repo: r.Throttles, Users: r.Users, Auth: cfg.Auth}
+	s.MFA = &service.MFAService{Users: r.Users, MFA: r.MFA, Roles: r.Roles, Tokens: s.Tokens, Lockout: s.Lockout, Auth: cfg.Auth}
+	s.OIDC = &service.OIDCService{
+		Client: oidc.New(cfg.OIDC), Repo: r.OIDC, Users: r.Users, Alumni: r.Alumni,
+		Roles: r.Roles, Tokens: s.Tokens, MFA: s.MFA, Tx: c.UoW,
+	}
+	s.APIKeys = &service.APIKeyService{Keys: r.APIKeys, Users: r.Users, Roles: r.Roles, Auth: cfg.Auth}
+	s.Auth = &service.AuthService{Users: r.Users, Sessions: r.Sessions, Tokens: s.Tokens, Lockout: s.Lockout, MFA: s.MFA}
+	if mongoDB != nil {
+		s.AlumniMongo = service.NewAlumniMongoService(r.AlumniMongo)
+		s.PekerjaanMongo = service.NewPekerjaanMongoService(r.PekerjaanMongo)
+	}
+	c.Services = s
+	return c
+}
+
+// Close menutup koneksi yang dibuka oleh New
+func (c *Container) Close(ctx context.Context) error {
+	if !c.ownsConns {
+		return nil
+	}
+	c.ownsConns = false
+	var errs []error
+	if c.Mongo != nil {
+		errs = append(errs, c.Mongo.Disconnect(ctx))
+	}
+	if c.DB != nil {
+		errs = append(errs, c.DB.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,46 @@
+-- Skema awal yang sebelumnya hanya tersirat di query app/repository.
+-- IF NOT EXISTS supaya database lama yang dibuat manual bisa ikut memakai migrasi.
+
+CREATE TABLE IF NOT EXISTS alumni (
+    id          SERIAL PRIMARY KEY,
+    nim         VARCHAR(20)  NOT NULL UNIQUE,
+    nama        VARCHAR(100) NOT NULL,
+    jurusan     VARCHAR(100) NOT NULL,
+    angkatan    INT          NOT NULL,
+    tahun_lulus INT          NOT NULL,
+    email       VARCHAR(100) NOT NULL UNIQUE,
+    no_telepon  VARCHAR(20),
+    alamat      TEXT,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS pekerjaan_alumni (
+    id                    SERIAL PRIMARY KEY,
+    alumni_id             INT          NOT NULL REFERENCES alumni(id) ON DELETE CASCADE,
+    nama_perusahaan       VARCHAR(100) NOT NULL,
+    posisi_jabatan        VARCHAR(100) NOT NULL,
+    bidang_industri       VARCHAR(50)  NOT NULL,
+    lokasi_kerja          VARCHAR(100) NOT NULL,
+    gaji_range            VARCHAR(50),
+    tanggal_mulai_kerja   DATE         NOT NULL,
+    tanggal_selesai_kerja DATE,
+    status_pekerjaan      VARCHAR(20)  NOT NULL DEFAULT 'aktif',
+    deskripsi_pekerjaan   TEXT,
+    created_at            TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMP    NOT NULL DEFAULT NOW(),
+    is_delete             BOOLEAN      NOT NULL DEFAULT FALSE,
+    deleted_at            TIMESTAMP,
+    deleted_by            VARCHAR(50)  NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_alumni_id ON pekerjaan_alumni (alumni_id);
+
+CREATE TABLE IF NOT EXISTS users (
+    id            SERIAL PRIMARY KEY,
+    username      VARCHAR(50)  NOT NULL UNIQUE,
+    email         VARCHAR(100) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    role          VARCHAR(50)  NOT NULL DEFAULT 'user',
+    alumni_id     INT          REFERENCES alumni(id) ON DELETE SET NULL
+);
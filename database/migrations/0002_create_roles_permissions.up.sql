@@ -0,0 +1,32 @@
+CREATE TABLE permissions (
+    name        VARCHAR(50) PRIMARY KEY,
+    description TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE TABLE roles (
+    name        VARCHAR(50) PRIMARY KEY,
+    description TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+    role_name  VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_name, permission)
+);
+
+-- role bawaan harus ada sebelum FK users.role dipasang; permission dan isi
+-- role_permissions dilengkapi oleh RoleRepository.EnsureBuiltinRoles saat start
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Administrator'),
+    ('staff', 'Staf fakultas'),
+    ('user', 'Alumni');
+
+-- role lama di luar role bawaan tetap dibuat supaya FK tidak gagal
+INSERT INTO roles (name)
+SELECT DISTINCT lower(role) FROM users
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE users SET role = lower(role) WHERE role <> lower(role);
+
+ALTER TABLE users
+    ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
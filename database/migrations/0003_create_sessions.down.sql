@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS auth_sessions;
+ALTER TABLE users DROP COLUMN IF EXISTS created_at;
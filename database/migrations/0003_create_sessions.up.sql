@@ -0,0 +1,32 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- satu sesi = satu keluarga refresh token dari satu kali login
+CREATE TABLE auth_sessions (
+    id         VARCHAR(64) PRIMARY KEY,
+    user_id    INT         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_auth_sessions_user_id ON auth_sessions (user_id);
+
+CREATE TABLE refresh_tokens (
+    id         SERIAL PRIMARY KEY,
+    session_id VARCHAR(64) NOT NULL REFERENCES auth_sessions(id) ON DELETE CASCADE,
+    token_hash CHAR(64)    NOT NULL UNIQUE,
+    expires_at TIMESTAMP   NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE password_reset_tokens (
+    id         SERIAL PRIMARY KEY,
+    user_id    INT       NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64)  NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
@@ -0,0 +1,25 @@
+-- penghitung gagal login per akun (scope 'account', key = user id) dan per IP
+CREATE TABLE login_throttles (
+    scope        VARCHAR(16)  NOT NULL,
+    key          VARCHAR(128) NOT NULL,
+    failures     INT          NOT NULL DEFAULT 0,
+    lockouts     INT          NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, key)
+);
+
+CREATE TABLE lockout_events (
+    id           SERIAL PRIMARY KEY,
+    scope        VARCHAR(16)  NOT NULL,
+    key          VARCHAR(128) NOT NULL,
+    user_id      INT          REFERENCES users(id) ON DELETE SET NULL,
+    event        VARCHAR(16)  NOT NULL,
+    failures     INT          NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    ip           VARCHAR(64)  NOT NULL DEFAULT '',
+    actor_id     INT          REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_lockout_events_user_id ON lockout_events (user_id);
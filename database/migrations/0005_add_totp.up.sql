@@ -0,0 +1,14 @@
+ALTER TABLE users
+    ADD COLUMN totp_secret    VARCHAR(64),
+    ADD COLUMN totp_enabled   BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN totp_last_step BIGINT  NOT NULL DEFAULT 0;
+
+CREATE TABLE recovery_codes (
+    id         SERIAL PRIMARY KEY,
+    user_id    INT       NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  CHAR(64)  NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes (user_id);
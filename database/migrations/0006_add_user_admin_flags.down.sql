@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_alumni_id;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS disabled,
+    DROP COLUMN IF EXISTS password_reset_required;
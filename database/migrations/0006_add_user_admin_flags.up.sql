@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN disabled                BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- satu alumni paling banyak terhubung ke satu akun
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_alumni_id ON users (alumni_id) WHERE alumni_id IS NOT NULL;
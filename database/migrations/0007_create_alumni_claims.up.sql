@@ -0,0 +1,17 @@
+CREATE TABLE alumni_claims (
+    id              SERIAL PRIMARY KEY,
+    user_id         INT         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    alumni_id       INT         NOT NULL REFERENCES alumni(id) ON DELETE CASCADE,
+    nim             VARCHAR(20) NOT NULL,
+    status          VARCHAR(16) NOT NULL,
+    note            TEXT,
+    code_hash       CHAR(64),
+    code_expires_at TIMESTAMP,
+    attempts        INT         NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP   NOT NULL DEFAULT NOW(),
+    resolved_at     TIMESTAMP,
+    resolved_by     INT         REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_alumni_claims_user_id ON alumni_claims (user_id);
+CREATE INDEX idx_alumni_claims_status ON alumni_claims (status);
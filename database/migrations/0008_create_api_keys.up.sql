@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+    id           SERIAL PRIMARY KEY,
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL UNIQUE,
+    user_id      INT          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    scopes       TEXT[]       NOT NULL DEFAULT '{}',
+    expires_at   TIMESTAMP    NOT NULL,
+    last_used_at TIMESTAMP,
+    created_by   INT          NOT NULL REFERENCES users(id),
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMP
+);
@@ -0,0 +1,14 @@
+CREATE TABLE oidc_login_states (
+    state_hash    CHAR(64)    PRIMARY KEY,
+    nonce         VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at    TIMESTAMP   NOT NULL
+);
+
+CREATE TABLE user_identities (
+    issuer     VARCHAR(255) NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    user_id    INT          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, subject)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_logs;
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS actor_id;
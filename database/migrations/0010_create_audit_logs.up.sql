@@ -0,0 +1,17 @@
+-- sesi impersonasi: user_id = user yang dilihat, actor_id = admin asli
+ALTER TABLE auth_sessions
+    ADD COLUMN actor_id INT REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE audit_logs (
+    id         SERIAL PRIMARY KEY,
+    actor_id   INT          NOT NULL REFERENCES users(id),
+    user_id    INT          NOT NULL REFERENCES users(id),
+    session_id VARCHAR(64)  NOT NULL,
+    method     VARCHAR(10)  NOT NULL,
+    path       TEXT         NOT NULL,
+    status     INT          NOT NULL,
+    ip         VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id);
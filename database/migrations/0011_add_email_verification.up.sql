@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN email_verified_at          TIMESTAMP,
+    ADD COLUMN email_verification_sent_at TIMESTAMP;
+
+-- akun yang sudah ada sebelum verifikasi email diwajibkan dianggap terverifikasi
+UPDATE users SET email_verified_at = created_at;
@@ -0,0 +1,2 @@
+-- bahasa respons API pilihan user; NULL = ikut header Accept-Language
+ALTER TABLE users ADD COLUMN locale VARCHAR(8);
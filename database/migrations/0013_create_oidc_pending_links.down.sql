@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS oidc_pending_links;
+
+ALTER TABLE oidc_login_states DROP COLUMN IF EXISTS link_user_id;
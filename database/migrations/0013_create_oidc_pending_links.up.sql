@@ -0,0 +1,13 @@
+-- state login SSO yang dimulai user yang sudah login untuk menautkan akunnya
+ALTER TABLE oidc_login_states
+    ADD COLUMN link_user_id INT REFERENCES users(id) ON DELETE CASCADE;
+
+-- hasil callback penautan; baru jadi user_identities setelah dikonfirmasi
+-- oleh user yang sama lewat request terautentikasi
+CREATE TABLE oidc_pending_links (
+    code_hash  CHAR(64)     PRIMARY KEY,
+    user_id    INT          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer     VARCHAR(255) NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP    NOT NULL
+);
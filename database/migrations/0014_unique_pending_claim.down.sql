@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_alumni_claims_pending;
@@ -0,0 +1,10 @@
+-- satu klaim pending per (user, alumni); klaim pending lama yang dobel ditolak
+UPDATE alumni_claims c
+SET status = 'rejected', resolved_at = NOW(), code_hash = NULL, code_expires_at = NULL
+WHERE status = 'pending' AND EXISTS (
+    SELECT 1 FROM alumni_claims n
+    WHERE n.user_id = c.user_id AND n.alumni_id = c.alumni_id
+      AND n.status = 'pending' AND n.id > c.id
+);
+
+CREATE UNIQUE INDEX idx_alumni_claims_pending ON alumni_claims (user_id, alumni_id) WHERE status = 'pending';
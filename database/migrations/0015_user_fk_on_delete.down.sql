@@ -0,0 +1,16 @@
+-- baris yang referensinya sudah dikosongkan tidak bisa dikembalikan ke NOT NULL
+DELETE FROM audit_logs WHERE actor_id IS NULL OR user_id IS NULL;
+DELETE FROM api_keys WHERE created_by IS NULL;
+
+ALTER TABLE api_keys
+    DROP CONSTRAINT api_keys_created_by_fkey,
+    ADD CONSTRAINT api_keys_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id),
+    ALTER COLUMN created_by SET NOT NULL;
+
+ALTER TABLE audit_logs
+    DROP CONSTRAINT audit_logs_actor_id_fkey,
+    DROP CONSTRAINT audit_logs_user_id_fkey,
+    ADD CONSTRAINT audit_logs_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id),
+    ADD CONSTRAINT audit_logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+    ALTER COLUMN actor_id SET NOT NULL,
+    ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,14 @@
+-- hapus user tidak boleh gagal karena jejak audit / API key buatannya;
+-- referensinya dikosongkan, barisnya tetap disimpan
+ALTER TABLE audit_logs
+    ALTER COLUMN actor_id DROP NOT NULL,
+    ALTER COLUMN user_id DROP NOT NULL,
+    DROP CONSTRAINT audit_logs_actor_id_fkey,
+    DROP CONSTRAINT audit_logs_user_id_fkey,
+    ADD CONSTRAINT audit_logs_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+    ADD CONSTRAINT audit_logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE api_keys
+    ALTER COLUMN created_by DROP NOT NULL,
+    DROP CONSTRAINT api_keys_created_by_fkey,
+    ADD CONSTRAINT api_keys_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
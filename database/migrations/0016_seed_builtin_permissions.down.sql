@@ -0,0 +1,2 @@
+-- sengaja kosong: permission dan grant bisa sudah diubah admin, dan versi
+-- sebelumnya juga mengisinya saat startup
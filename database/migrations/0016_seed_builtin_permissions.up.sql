@@ -0,0 +1,18 @@
+-- permission bawaan dan grant awal role bawaan diisi sekali lewat migrasi;
+-- startup hanya membuat role yang belum ada, sehingga permission yang dicabut
+-- admin tidak muncul lagi. Permission baru ditambahkan dengan migrasi baru.
+INSERT INTO permissions (name, description) VALUES
+    ('alumni:write', 'Tambah, ubah, dan hapus data alumni'),
+    ('pekerjaan:write', 'Kelola pekerjaan milik alumni mana pun'),
+    ('pekerjaan:hard_delete', 'Hapus permanen pekerjaan alumni mana pun'),
+    ('pekerjaan:trash_all', 'Lihat trash pekerjaan semua alumni'),
+    ('users:manage', 'Kelola akun user, role, dan admin'),
+    ('users:impersonate', 'Melihat aplikasi sebagai user lain (impersonasi)')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission)
+SELECT 'admin', name FROM permissions
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission) VALUES ('staff', 'alumni:write')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,265 @@
+// Package migrations berisi migrasi skema Postgres (file NNNN_nama.up.sql /
+// NNNN_nama.down.sql di folder ini, ikut di-embed ke binary) beserta runner-nya.
+// Versi yang sudah dijalankan dicatat di tabel schema_migrations.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// FS adalah migrasi yang di-embed ke binary
+func FS() fs.FS { return files }
+
+// lockKey adalah kunci pg_advisory_lock supaya dua proses migrasi tidak jalan bersamaan
+const lockKey = 727_001_015
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration adalah satu versi skema
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status adalah satu baris output perintah status
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Load membaca dan mengurutkan migrasi dari fsys. Setiap versi wajib punya
+// file up dan down.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("nama file migrasi tidak valid: %s", e.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("versi %d dipakai dua nama: %s dan %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrasi %04d_%s harus punya file up dan down", m.Version, m.Name)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Migrator menjalankan migrasi terhadap satu database. Setiap migrasi jalan
+// dalam transaksinya sendiri, dan seluruh proses memegang advisory lock.
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+}
+
+// New memuat migrasi yang di-embed
+func New(db *sql.DB) (*Migrator, error) {
+	list, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, Migrations: list}, nil
+}
+
+// withLock menjalankan fn di satu koneksi yang memegang advisory lock.
+// Proses lain yang memanggil migrasi akan menunggu sampai lock dilepas.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("gagal mengambil advisory lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT      NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func applied(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int64]time.Time{}
+	for rows.Next() {
+		var v int64
+		var at time.Time
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, err
+		}
+		done[v] = at
+	}
+	return done, rows.Err()
+}
+
+// Up menjalankan semua migrasi yang belum diterapkan, berurutan.
+// Mengembalikan migrasi yang baru dijalankan.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var ran []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.Migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			if err := run(ctx, conn, mig, mig.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name); err != nil {
+				return err
+			}
+			ran = append(ran, mig)
+		}
+		return nil
+	})
+	return ran, err
+}
+
+// Down membatalkan steps migrasi terakhir yang sudah diterapkan
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var ran []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.Migrations) - 1; i >= 0 && len(ran) < steps; i-- {
+			mig := m.Migrations[i]
+			if _, ok := done[mig.Version]; !ok {
+				continue
+			}
+			if err := run(ctx, conn, mig, mig.Down, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version); err != nil {
+				return err
+			}
+			ran = append(ran, mig)
+		}
+		return nil
+	})
+	return ran, err
+}
+
+// Status mengembalikan semua migrasi beserta waktu diterapkan (nil = belum)
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var list []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.Migrations {
+			st := Status{Migration: mig}
+			if at, ok := done[mig.Version]; ok {
+				st.AppliedAt = &at
+			}
+			list = append(list, st)
+		}
+		return nil
+	})
+	return list, err
+}
+
+// run mengeksekusi body migrasi dan mencatatnya di schema_migrations dalam satu transaksi
+func run(ctx context.Context, conn *sql.Conn, mig Migration, body, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("migrasi %04d_%s gagal: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Create membuat pasangan file migrasi kosong di dir dengan versi berikutnya
+func Create(dir, name string) (up, down string, err error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return "", "", fmt.Errorf("nama migrasi wajib diisi")
+	}
+
+	list, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	next := int64(1)
+	if len(list) > 0 {
+		next = list[len(list)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", next, name))
+	up, down = base+".up.sql", base+".down.sql"
+	if err := os.WriteFile(up, []byte("-- tulis perubahan skema di sini\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte("-- batalkan perubahan dari file .up.sql pasangannya\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
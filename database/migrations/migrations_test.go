@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go_clean/app/models"
+)
+
+func file(body string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(body)}
+}
+
+func TestLoadOrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_ten.up.sql":   file("up 10"),
+		"0010_ten.down.sql": file("down 10"),
+		"0002_two.up.sql":   file("up 2"),
+		"0002_two.down.sql": file("down 2"),
+		"0001_one.up.sql":   file("up 1"),
+		"0001_one.down.sql": file("down 1"),
+		"README.md":         file("bukan migrasi"),
+	}
+	list, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		version  int64
+		name, up string
+	}{{1, "one", "up 1"}, {2, "two", "up 2"}, {10, "ten", "up 10"}}
+	if len(list) != len(want) {
+		t.Fatalf("dapat %d migrasi, want %d", len(list), len(want))
+	}
+	for i, w := range want {
+		m := list[i]
+		if m.Version != w.version || m.Name != w.name || m.Up != w.up {
+			t.Errorf("list[%d] = %d_%s %q, want %d_%s %q", i, m.Version, m.Name, m.Up, w.version, w.name, w.up)
+		}
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "tanpa down",
+			fsys: fstest.MapFS{"0001_one.up.sql": file("up")},
+			want: "harus punya file up dan down",
+		},
+		{
+			name: "nama file salah",
+			fsys: fstest.MapFS{"1-one.up.sql": file("up")},
+			want: "nama file migrasi tidak valid",
+		},
+		{
+			name: "versi dipakai dua nama",
+			fsys: fstest.MapFS{
+				"0001_one.up.sql":   file("up"),
+				"0001_uno.down.sql": file("down"),
+			},
+			want: "dipakai dua nama",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want mengandung %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestEmbedded memastikan migrasi yang ikut di binary lengkap dan versinya
+// berurutan tanpa celah
+func TestEmbedded(t *testing.T) {
+	list, err := Load(FS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 {
+		t.Fatal("tidak ada migrasi yang di-embed")
+	}
+	for i, m := range list {
+		if m.Version != int64(i+1) {
+			t.Fatalf("migrasi ke-%d berversi %d, want %d", i, m.Version, i+1)
+		}
+	}
+}
+
+// TestBuiltinPermissionsSeeded memastikan setiap permission bawaan diisi oleh
+// migrasi, karena startup tidak lagi membuatnya
+func TestBuiltinPermissionsSeeded(t *testing.T) {
+	list, err := Load(FS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var up strings.Builder
+	for _, m := range list {
+		up.WriteString(m.Up)
+	}
+	for _, p := range models.BuiltinPermissions() {
+		if !strings.Contains(up.String(), "'"+p.Name+"'") {
+			t.Errorf("permission %q belum diisi migrasi mana pun", p.Name)
+		}
+	}
+}
@@ -2,42 +2,33 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"os"
 	"time"
 
+	"go_clean/config"
+
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var MongoDB *mongo.Database
-
-func ConnectMongoDB() {
-	uri := os.Getenv("MONGO_URI")
-	dbName := os.Getenv("MONGO_DB")
-
-	if uri == "" {
-		uri = "mongodb://localhost:27017"
-		log.Println("⚠️  MONGO_URI tidak diset, gunakan default:", uri)
-	}
-	if dbName == "" {
-		dbName = "alumni_db"
-		log.Println("⚠️  MONGO_DB tidak diset, gunakan default:", dbName)
-	}
-
-	clientOpts := options.Client().ApplyURI(uri)
+// ConnectMongoDB membuka client MongoDB. Database yang dipakai aplikasi
+// adalah client.Database(cfg.Database); pemanggil wajib Disconnect client.
+func ConnectMongoDB(cfg config.MongoConfig) (*mongo.Client, error) {
+	clientOpts := options.Client().ApplyURI(cfg.URI)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
-		log.Fatalf("❌ Gagal koneksi MongoDB: %v", err)
+		return nil, fmt.Errorf("❌ Gagal koneksi MongoDB: %w", err)
 	}
 
 	if err := client.Ping(ctx, nil); err != nil {
-		log.Fatalf("❌ Gagal ping MongoDB: %v", err)
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("❌ Gagal ping MongoDB: %w", err)
 	}
 
-	MongoDB = client.Database(dbName)
-	log.Println("✅ Berhasil konek MongoDB:", dbName)
+	log.Println("✅ Berhasil konek MongoDB:", cfg.Database)
+	return client, nil
 }
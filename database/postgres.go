@@ -2,30 +2,27 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
-	"os"
+
+	"go_clean/config"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
-var DB *sql.DB
-
-// ConnectDB menginisialisasi koneksi ke database
-func ConnectDB() {
-	dsn := os.Getenv("DB_DSN")
-	if dsn == "" {
-		log.Fatal("DB_DSN environment variable not set")
-	}
-
-	var err error
-	DB, err = sql.Open("postgres", dsn)
+// ConnectDB membuka pool koneksi PostgreSQL dan memastikan database bisa dihubungi.
+// Pemanggil yang memiliki pool bertanggung jawab menutupnya.
+func ConnectDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN)
 	if err != nil {
-		log.Fatalf("Could not connect to the database: %v", err)
+		return nil, fmt.Errorf("could not connect to the database: %w", err)
 	}
 
-	if err = DB.Ping(); err != nil {
-		log.Fatalf("Could not ping the database: %v", err)
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not ping the database: %w", err)
 	}
 
 	log.Println("Successfully connected to the database")
-}
\ No newline at end of file
+	return db, nil
+}
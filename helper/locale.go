@@ -0,0 +1,24 @@
+package helper
+
+import (
+	"go_clean/app/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale menentukan bahasa respons: preferensi user (diisi AuthRequired dari
+// token) lebih dulu, lalu header Accept-Language, lalu i18n.Default
+func Locale(c *fiber.Ctx) i18n.Locale {
+	if l, ok := c.Locals("locale").(i18n.Locale); ok {
+		return l
+	}
+	if l, ok := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage)); ok {
+		return l
+	}
+	return i18n.Default
+}
+
+// Message mengembalikan pesan katalog untuk code dalam bahasa request
+func Message(c *fiber.Ctx, code string, args ...any) string {
+	return i18n.T(Locale(c), code, args...)
+}
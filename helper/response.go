@@ -10,12 +10,3 @@ func SuccessResponse(c *fiber.Ctx, data interface{}, message string) error {
 		"data":    data,
 	})
 }
-
-// ErrorResponse membuat respons JSON untuk kasus error
-func ErrorResponse(c *fiber.Ctx, statusCode int, message string) error {
-	return c.Status(statusCode).JSON(fiber.Map{
-		"success": false,
-		"message": message,
-	})
-}
-
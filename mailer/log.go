@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogMailer tidak benar-benar mengirim email, hanya menuliskannya ke file
+// (atau ke log server jika Path kosong). Cocok untuk development lokal.
+type LogMailer struct {
+	Path string
+	From string
+
+	mu sync.Mutex
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	entry := fmt.Sprintf("=== %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), m.From, msg.To, msg.Subject, msg.Body)
+
+	if m.Path == "" {
+		log.Print("📧 " + entry)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(entry)
+	return err
+}
@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"go_clean/config"
+)
+
+// Message adalah email teks sederhana yang dikirim aplikasi
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer mengirim email; implementasinya bisa SMTP atau file/log untuk lokal
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New memilih implementasi Mailer sesuai MAIL_DRIVER
+func New(cfg config.MailConfig) Mailer {
+	if cfg.Driver == "smtp" {
+		return &SMTPMailer{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.From,
+		}
+	}
+	return &LogMailer{Path: cfg.LogFile, From: cfg.From}
+}
@@ -2,14 +2,18 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"go_clean/app/handlers"
 	"go_clean/config"
-	"go_clean/database"
+	"go_clean/container"
+	"go_clean/middleware"
 	"go_clean/route"
 
 	"github.com/gofiber/fiber/v2"
@@ -19,63 +23,65 @@ import (
 )
 
 func main() {
-	// 1️⃣ Load env
-	config.LoadEnv()
+	// 1️⃣ Load config: default → file → env (.env opsional) → flag
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	cfgFlags := config.BindFlags(fs)
+	printConfig := fs.Bool("print-config", false, "cetak config akhir (rahasia disamarkan) lalu keluar")
+	fs.Parse(os.Args[1:])
 
-	// 2️⃣ Connect ke PostgreSQL
-	database.ConnectDB()
-	defer database.DB.Close()
+	cfg, err := config.Load(cfgFlags)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *printConfig {
+		fmt.Print(cfg)
+		return
+	}
 
-	// 3️⃣ Connect ke MongoDB
-	database.ConnectMongoDB()
+	// 2️⃣ Rakit container: key JWT, PostgreSQL, MongoDB, repository, service
+	deps, err := container.New(context.Background(), cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer deps.Close(context.Background())
 
-	// 4️⃣ Setup Fiber app
+	// 3️⃣ Setup Fiber app
 	app := fiber.New(fiber.Config{
 		BodyLimit: 10 * 1024 * 1024,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"error":   true,
-				"message": err.Error(),
-			})
-		},
+		// semua error (service, middleware, Fiber) dipetakan di satu tempat
+		ErrorHandler: handlers.ErrorHandler,
 	})
 
-	// 5️⃣ Middleware
-	if os.Getenv("APP_ENV") != "production" {
+	// 4️⃣ Middleware
+	if cfg.App.Env != "production" {
 		app.Use(logger.New())
 	}
 	app.Use(recover.New())
 	app.Use(cors.New())
+	// context per request dengan batas waktu; dibatalkan juga saat shutdown
+	baseCtx, stopRequests := context.WithCancel(context.Background())
+	defer stopRequests()
+	app.Use(middleware.Timeout(baseCtx, cfg.App.RequestTimeout, cfg.App.RouteTimeouts))
 
-	// 6️⃣ Root sederhana
+	// 5️⃣ Root sederhana
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.SendString("Welcome to Alumni API 🚀")
 	})
 
-	// 7️⃣ Register routes (Postgres + Mongo)
-	route.SetupPekerjaanMongoRoutes(app, database.MongoDB)
-	route.SetupAlumniMongoRoutes(app, database.MongoDB)
-	route.SetupRoutes(app, database.DB, database.MongoDB)
+	// 6️⃣ Register routes (Postgres + Mongo)
+	route.SetupRoutes(app, deps)
 
 
-	// 8️⃣ Start server
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "8080"
-	}
-
+	// 7️⃣ Start server
+	addr := fmt.Sprintf(":%d", cfg.App.Port)
 	go func() {
-		log.Printf("Server running on :%s", port)
-		if err := app.Listen(":" + port); err != nil {
+		log.Printf("Server running on %s", addr)
+		if err := app.Listen(addr); err != nil {
 			log.Printf("Server stopped: %v", err)
 		}
 	}()
 
-	// 9️⃣ Graceful shutdown
+	// 8️⃣ Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
@@ -85,4 +91,6 @@ func main() {
 	if err := app.ShutdownWithContext(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	// request yang belum selesai setelah masa tenggang: batalkan query-nya
+	stopRequests()
 }
@@ -1,40 +1,226 @@
 package middleware
 
 import (
+	"context"
+	"database/sql"
+	"log"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"go_clean/app/apperror"
+	"go_clean/app/i18n"
+	"go_clean/app/models"
 	"go_clean/utils"
 )
 
-func AuthRequired() fiber.Handler {
+// TokenValidator memverifikasi token dengan salah satu purpose yang diharapkan
+// (lihat utils.JWT)
+type TokenValidator interface {
+	ValidateToken(tokenStr string, purposes ...string) (*models.JWTClaims, error)
+}
+
+// SessionChecker dipakai AuthRequired untuk menolak token dari sesi yang sudah dicabut
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// APIKeyChecker dipakai AuthRequired untuk autentikasi API key integrasi
+type APIKeyChecker interface {
+	GetActiveAPIKey(ctx context.Context, hash string) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id int) error
+}
+
+// AuditRecorder mencatat aksi tulis yang dilakukan selama impersonasi
+type AuditRecorder interface {
+	Record(ctx context.Context, e models.AuditEntry) error
+}
+
+// AuthRequired menerima Bearer JWT (user) atau API key (integrasi) lewat
+// "Authorization: ApiKey <key>" / header X-API-Key. Keduanya mengisi
+// c.Locals yang sama; untuk API key, permissions = scopes key tersebut yang
+// masih dimiliki role pemiliknya, tanpa permission yang mewajibkan 2FA.
+//
+// Token impersonasi (claim act) juga mengisi actor_id/actor_username, dan
+// setiap request tulisnya dicatat ke audit atas nama admin asli.
+func AuthRequired(tokens TokenValidator, sessions SessionChecker, keys APIKeyChecker, audit AuditRecorder) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if key := c.Get("X-API-Key"); key != "" {
+			return apiKeyAuth(c, keys, key)
+		}
 		auth := c.Get("Authorization")
 		if auth == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "butuh token"})
+			return apperror.Unauthorized("token_required")
 		}
 		parts := strings.Split(auth, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "format token salah"})
+		if len(parts) != 2 {
+			return apperror.Unauthorized("token_malformed")
+		}
+		switch parts[0] {
+		case "Bearer":
+		case "ApiKey":
+			return apiKeyAuth(c, keys, parts[1])
+		default:
+			return apperror.Unauthorized("token_malformed")
 		}
-		claims, err := utils.ValidateToken(parts[1])
+		claims, err := tokens.ValidateToken(parts[1], models.TokenPurposeAccess, models.TokenPurposeImpersonation)
+		if err != nil || claims.SessionID == "" ||
+			(claims.Actor != nil) != (claims.Purpose == models.TokenPurposeImpersonation) {
+			return apperror.Unauthorized("token_invalid")
+		}
+		active, err := sessions.IsSessionActive(c.UserContext(), claims.SessionID)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "token invalid/expired"})
+			return apperror.Wrap(err, "session_check_failed")
+		}
+		if !active {
+			return apperror.Unauthorized("session_revoked")
 		}
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Username)
 		c.Locals("role", claims.Role)
+		c.Locals("permissions", claims.Permissions)
+		c.Locals("session_id", claims.SessionID)
+		c.Locals("email_verified", claims.EmailVerified)
+		// preferensi bahasa user mengalahkan Accept-Language (lihat helper.Locale)
+		if l, ok := i18n.Parse(claims.Locale); ok {
+			c.Locals("locale", l)
+		}
+		if claims.Actor == nil {
+			return c.Next()
+		}
+		c.Locals("actor_id", claims.Actor.UserID)
+		c.Locals("actor_username", claims.Actor.Username)
+		return auditWrite(c, audit, claims.Actor.UserID, claims.UserID, claims.SessionID)
+	}
+}
+
+// auditWrite menjalankan handler lalu mencatat hasilnya jika request mengubah data
+func auditWrite(c *fiber.Ctx, audit AuditRecorder, actorID, userID int, sessionID string) error {
+	switch c.Method() {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
 		return c.Next()
 	}
+	err := c.Next()
+	status := c.Response().StatusCode()
+	if e, ok := err.(*fiber.Error); ok {
+		status = e.Code
+	} else if err != nil {
+		// respons error baru ditulis ErrorHandler setelah middleware ini selesai
+		status = apperror.KindOf(err).Status()
+	}
+	// tetap dicatat walaupun request sudah timeout / dibatalkan
+	ctx := context.WithoutCancel(c.UserContext())
+	if recErr := audit.Record(ctx, models.AuditEntry{
+		ActorID:   actorID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Method:    c.Method(),
+		Path:      c.OriginalURL(),
+		Status:    status,
+		IP:        c.IP(),
+	}); recErr != nil {
+		log.Printf("audit impersonasi gagal dicatat: actor=%d user=%d %s %s: %v", actorID, userID, c.Method(), c.OriginalURL(), recErr)
+	}
+	return err
 }
 
-func AdminOnly() fiber.Handler {
+// ActorID mengembalikan admin asli saat impersonasi, atau user_id jika tidak.
+// Dipakai untuk kolom jejak seperti deleted_by.
+func ActorID(c *fiber.Ctx) int {
+	if id, ok := c.Locals("actor_id").(int); ok {
+		return id
+	}
+	id, _ := c.Locals("user_id").(int)
+	return id
+}
+
+// Impersonating true jika request memakai token impersonasi
+func Impersonating(c *fiber.Ctx) bool {
+	_, ok := c.Locals("actor_id").(int)
+	return ok
+}
+
+func apiKeyAuth(c *fiber.Ctx, keys APIKeyChecker, raw string) error {
+	ctx := c.UserContext()
+	if !strings.HasPrefix(raw, models.APIKeyPrefix) {
+		return apperror.Unauthorized("api_key_malformed")
+	}
+	key, err := keys.GetActiveAPIKey(ctx, utils.HashToken(raw))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.Unauthorized("api_key_invalid")
+		}
+		return apperror.Wrap(err, "api_key_check_failed")
+	}
+	if err := keys.TouchAPIKey(ctx, key.ID); err != nil {
+		return apperror.Wrap(err, "api_key_check_failed")
+	}
+	c.Locals("user_id", key.UserID)
+	c.Locals("username", key.Username)
+	c.Locals("role", key.Role)
+	// key tidak pernah melewati 2FA, jadi permission privileged dibuang walaupun
+	// tersimpan di key lama
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, s := range key.Scopes {
+		if !models.PrivilegedPermission(s) {
+			scopes = append(scopes, s)
+		}
+	}
+	c.Locals("permissions", scopes)
+	c.Locals("api_key_id", key.ID)
+	c.Locals("email_verified", true) // key diterbitkan admin untuk akun layanan
+	return c.Next()
+}
+
+// SessionOnly menolak request yang diautentikasi dengan API key atau token
+// impersonasi, untuk endpoint yang hanya boleh dilakukan pemilik akun sendiri
+// (2FA, ganti password, kelola API key, impersonasi).
+func SessionOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("api_key_id").(int); ok {
+			return apperror.Forbidden("api_key_not_allowed")
+		}
+		if Impersonating(c) {
+			return apperror.Forbidden("impersonation_not_allowed")
+		}
+		return c.Next()
+	}
+}
+
+// VerifiedEmailForWrites membatasi akun yang emailnya belum terverifikasi
+// ke request baca saja (GET/HEAD/OPTIONS)
+func VerifiedEmailForWrites() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+		if verified, _ := c.Locals("email_verified").(bool); !verified {
+			return apperror.Forbidden("email_not_verified")
+		}
+		return c.Next()
+	}
+}
+
+// Require memastikan principal punya SEMUA permission yang disebut.
+// Dipasang setelah AuthRequired, menggantikan pengecekan role == "admin".
+func Require(perms ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		role, _ := c.Locals("role").(string)
-		if role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "admin only"})
+		for _, p := range perms {
+			if !HasPermission(c, p) {
+				return apperror.Forbidden("permission_denied", p)
+			}
 		}
 		return c.Next()
 	}
 }
 
+// HasPermission mengecek permission principal dari token pada request ini
+func HasPermission(c *fiber.Ctx, perm string) bool {
+	perms, _ := c.Locals("permissions").([]string)
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
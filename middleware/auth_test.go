@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+
+	"go_clean/app/handlers"
+	"go_clean/app/models"
+	"go_clean/middleware"
+	"go_clean/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeKeys memetakan hash key ke key aktifnya
+type fakeKeys map[string]*models.APIKey
+
+func (f fakeKeys) GetActiveAPIKey(ctx context.Context, hash string) (*models.APIKey, error) {
+	if k, ok := f[hash]; ok {
+		return k, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (fakeKeys) TouchAPIKey(ctx context.Context, id int) error { return nil }
+
+func TestAPIKeyDropsPrivilegedScopes(t *testing.T) {
+	raw := models.APIKeyPrefix + "rahasia"
+	keys := fakeKeys{utils.HashToken(raw): {
+		ID: 1, UserID: 9, Username: "registrar", Role: "admin",
+		Scopes: []string{models.PermAlumniWrite, models.PermUsersManage},
+	}}
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Use(middleware.AuthRequired(nil, nil, keys, nil))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/alumni", middleware.Require(models.PermAlumniWrite), ok)
+	app.Get("/users", middleware.Require(models.PermUsersManage), ok)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/alumni", fiber.StatusOK},
+		{"/users", fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+		req.Header.Set("X-API-Key", raw)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("GET %s: status = %d, want %d", tt.path, resp.StatusCode, tt.want)
+		}
+	}
+}
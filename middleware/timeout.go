@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go_clean/app/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type routeTimeout struct {
+	method string // kosong = semua method
+	prefix string
+	d      time.Duration
+}
+
+// Timeout memasang context per request (c.UserContext) yang diturunkan dari
+// base dan dibatasi waktu. base dibatalkan saat server berhenti, sehingga
+// query yang masih berjalan ikut dihentikan.
+//
+// routes berisi override per route dengan key "METHOD /prefix" atau "/prefix";
+// prefix terpanjang yang cocok yang dipakai, selain itu def.
+//
+// Error handler yang membungkus context.DeadlineExceeded diganti error 504;
+// error handler saat base sudah dibatalkan (shutdown) diganti 503. Handler
+// yang tetap berhasil walaupun melewati batas waktu tidak diubah responsnya.
+func Timeout(base context.Context, def time.Duration, routes map[string]time.Duration) fiber.Handler {
+	rules := make([]routeTimeout, 0, len(routes))
+	for key, d := range routes {
+		method, prefix, ok := strings.Cut(strings.TrimSpace(key), " ")
+		if !ok {
+			method, prefix = "", method
+		}
+		rules = append(rules, routeTimeout{
+			method: strings.ToUpper(method),
+			prefix: strings.TrimSpace(prefix),
+			d:      d,
+		})
+	}
+	// prefix terpanjang dulu; method spesifik menang atas semua method
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].prefix) != len(rules[j].prefix) {
+			return len(rules[i].prefix) > len(rules[j].prefix)
+		}
+		return rules[i].method > rules[j].method
+	})
+
+	return func(c *fiber.Ctx) error {
+		d := def
+		for _, r := range rules {
+			if (r.method == "" || r.method == c.Method()) && strings.HasPrefix(c.Path(), r.prefix) {
+				d = r.d
+				break
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(base, d)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		switch {
+		case err == nil:
+		case errors.Is(err, context.DeadlineExceeded):
+			return apperror.New(apperror.KindTimeout, "request_timeout").WithErr(err)
+		case base.Err() != nil:
+			return apperror.New(apperror.KindUnavailable, "server_shutting_down").WithErr(err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,122 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_clean/app/apperror"
+	"go_clean/app/handlers"
+	"go_clean/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newTimeoutApp memasang Timeout dengan batas waktu d di depan handler h
+func newTimeoutApp(base context.Context, d time.Duration, routes map[string]time.Duration, h fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Use(middleware.Timeout(base, d, routes))
+	app.All("/*", h)
+	return app
+}
+
+func status(t *testing.T, app *fiber.App, method, path string) int {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(method, path, nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestTimeout(t *testing.T) {
+	const limit = 20 * time.Millisecond
+	tests := []struct {
+		name string
+		h    fiber.Handler
+		want int
+	}{
+		{
+			name: "selesai sebelum batas waktu",
+			h:    func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) },
+			want: fiber.StatusOK,
+		},
+		{
+			// handler yang tetap berhasil tidak boleh diganti 504 hanya
+			// karena ctx sudah kedaluwarsa saat ia kembali
+			name: "berhasil walaupun melewati batas waktu",
+			h: func(c *fiber.Ctx) error {
+				time.Sleep(2 * limit)
+				return c.SendStatus(fiber.StatusCreated)
+			},
+			want: fiber.StatusCreated,
+		},
+		{
+			name: "error yang membungkus DeadlineExceeded",
+			h: func(c *fiber.Ctx) error {
+				<-c.UserContext().Done()
+				return fmt.Errorf("query alumni: %w", c.UserContext().Err())
+			},
+			want: fiber.StatusGatewayTimeout,
+		},
+		{
+			name: "error lain setelah batas waktu",
+			h: func(c *fiber.Ctx) error {
+				<-c.UserContext().Done()
+				return apperror.NotFound("alumni_not_found")
+			},
+			want: fiber.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTimeoutApp(context.Background(), limit, nil, tt.h)
+			if got := status(t, app, fiber.MethodGet, "/api/alumni"); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeoutShutdown(t *testing.T) {
+	base, cancel := context.WithCancel(context.Background())
+	cancel()
+	app := newTimeoutApp(base, time.Minute, nil, func(c *fiber.Ctx) error {
+		return c.UserContext().Err()
+	})
+	if got := status(t, app, fiber.MethodGet, "/api/alumni"); got != fiber.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", got, fiber.StatusServiceUnavailable)
+	}
+}
+
+func TestTimeoutRouteOverride(t *testing.T) {
+	routes := map[string]time.Duration{
+		"/api/admin":          time.Hour,
+		"POST /api/admin/job": 2 * time.Hour,
+	}
+	tests := []struct {
+		method, path string
+		want         time.Duration
+	}{
+		{fiber.MethodGet, "/api/alumni", time.Minute},
+		{fiber.MethodGet, "/api/admin/users", time.Hour},
+		{fiber.MethodGet, "/api/admin/job", time.Hour},
+		{fiber.MethodPost, "/api/admin/job", 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		var got time.Duration
+		app := newTimeoutApp(context.Background(), time.Minute, routes, func(c *fiber.Ctx) error {
+			deadline, _ := c.UserContext().Deadline()
+			got = time.Until(deadline)
+			return nil
+		})
+		status(t, app, tt.method, tt.path)
+		// toleransi untuk waktu yang berlalu selama request
+		if got > tt.want || got < tt.want-time.Second {
+			t.Errorf("%s %s: batas waktu %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package oidc adalah client OpenID Connect minimal untuk login SSO kampus:
+// discovery, authorization code + PKCE (S256), dan verifikasi ID token via JWKS.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go_clean/config"
+)
+
+// Provider adalah hasil discovery (.well-known/openid-configuration)
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Client menyimpan hasil discovery dan JWKS provider. Discovery dilakukan saat
+// dibutuhkan pertama kali, jadi server tetap bisa start walau SSO sedang down.
+type Client struct {
+	Config config.OIDCConfig
+	HTTP   *http.Client
+
+	mu       sync.Mutex
+	provider *Provider
+	keys     *keyCache
+}
+
+func New(cfg config.OIDCConfig) *Client {
+	return &Client{Config: cfg, HTTP: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Discover membaca (dan meng-cache) metadata provider
+func (c *Client) Discover(ctx context.Context) (*Provider, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.provider != nil {
+		return c.provider, nil
+	}
+
+	var p Provider
+	if err := c.getJSON(ctx, c.Config.Issuer+"/.well-known/openid-configuration", &p); err != nil {
+		return nil, fmt.Errorf("discovery gagal: %w", err)
+	}
+	if strings.TrimSuffix(p.Issuer, "/") != c.Config.Issuer {
+		return nil, fmt.Errorf("issuer discovery %q tidak sama dengan konfigurasi %q", p.Issuer, c.Config.Issuer)
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JWKSURI == "" {
+		return nil, errors.New("metadata discovery tidak lengkap")
+	}
+	c.provider = &p
+	c.keys = &keyCache{client: c, uri: p.JWKSURI}
+	return c.provider, nil
+}
+
+// PKCEChallenge menghitung code_challenge S256 dari code_verifier (RFC 7636)
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL membuat URL redirect ke halaman login provider
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	p, err := c.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.Config.ClientID},
+		"redirect_uri":          {c.Config.RedirectURL},
+		"scope":                 {strings.Join(c.Config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {PKCEChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange menukar authorization code dengan token lalu memverifikasi ID token-nya
+func (c *Client) Exchange(ctx context.Context, code, verifier, nonce string) (Claims, error) {
+	p, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.Config.RedirectURL},
+		"client_id":     {c.Config.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.Config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.Config.ClientID), url.QueryEscape(c.Config.ClientSecret))
+	}
+
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	var tr tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("respon token endpoint tidak valid: %w", err)
+	}
+	if res.StatusCode != http.StatusOK || tr.Error != "" {
+		return nil, fmt.Errorf("token endpoint menolak: %s %s", tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return nil, errors.New("respon token tanpa id_token")
+	}
+	return c.Verify(ctx, tr.IDToken, nonce)
+}
+
+func (c *Client) getJSON(ctx context.Context, u string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", u, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(dst)
+}
@@ -0,0 +1,252 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go_clean/config"
+)
+
+// mockProvider adalah provider OIDC lokal: discovery, token endpoint (dengan
+// pengecekan PKCE), dan JWKS. Code yang valid dipetakan ke challenge + nonce.
+type mockProvider struct {
+	t      *testing.T
+	srv    *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+	codes  map[string]mockCode
+	claims jwt.MapClaims // claim tambahan / override untuk id_token berikutnya
+	issuer string        // override issuer di dokumen discovery
+}
+
+type mockCode struct {
+	challenge string
+	nonce     string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockProvider{t: t, key: key, kid: "k1", codes: map[string]mockCode{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := m.srv.URL
+		if m.issuer != "" {
+			issuer = m.issuer
+		}
+		json.NewEncoder(w).Encode(Provider{
+			Issuer:                issuer,
+			AuthorizationEndpoint: m.srv.URL + "/authorize",
+			TokenEndpoint:         m.srv.URL + "/token",
+			JWKSURI:               m.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": m.kid,
+			"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", m.token)
+	m.srv = httptest.NewServer(mux)
+	t.Cleanup(m.srv.Close)
+	return m
+}
+
+func (m *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	code, ok := m.codes[r.Form.Get("code")]
+	if !ok || r.Form.Get("grant_type") != "authorization_code" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if PKCEChallenge(r.Form.Get("code_verifier")) != code.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "PKCE"})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"id_token": m.idToken(code.nonce), "token_type": "Bearer"})
+}
+
+func (m *mockProvider) idToken(nonce string) string {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            m.srv.URL,
+		"aud":            "alumni-api",
+		"sub":            "sso-123",
+		"nonce":          nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          "budi@kampus.ac.id",
+		"email_verified": true,
+		"nim":            "2101001",
+	}
+	for k, v := range m.claims {
+		claims[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = m.kid
+	s, err := tok.SignedString(m.key)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	return s
+}
+
+// authorize mensimulasikan user login di provider: mengembalikan code
+// untuk parameter yang dikirim client lewat AuthCodeURL
+func (m *mockProvider) authorize(authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	q := u.Query()
+	code := "code-" + q.Get("state")
+	m.codes[code] = mockCode{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	return code
+}
+
+func newTestClient(m *mockProvider) *Client {
+	return New(config.OIDCConfig{
+		Enabled:     true,
+		Issuer:      m.srv.URL,
+		ClientID:    "alumni-api",
+		RedirectURL: "http://localhost/api/oidc/callback",
+		Scopes:      []string{"openid", "email"},
+	})
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	m := newMockProvider(t)
+	c := newTestClient(m)
+
+	raw, err := c.AuthCodeURL(context.Background(), "st", "nn", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(raw)
+	q := u.Query()
+	if !strings.HasPrefix(raw, m.srv.URL+"/authorize?") {
+		t.Errorf("endpoint salah: %s", raw)
+	}
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "alumni-api",
+		"state":                 "st",
+		"nonce":                 "nn",
+		"scope":                 "openid email",
+		"code_challenge":        PKCEChallenge("verifier"),
+		"code_challenge_method": "S256",
+	}
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Errorf("%s = %q, want %q", k, q.Get(k), v)
+		}
+	}
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// contoh dari RFC 7636 lampiran B
+	got := PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("challenge = %s", got)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	m := newMockProvider(t)
+	c := newTestClient(m)
+	ctx := context.Background()
+
+	authURL, err := c.AuthCodeURL(ctx, "st", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := m.authorize(authURL)
+
+	claims, err := c.Exchange(ctx, code, "verifier-1", "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject() != "sso-123" || claims.String("nim") != "2101001" || !claims.Bool("email_verified") {
+		t.Errorf("claims tidak sesuai: %v", claims)
+	}
+}
+
+func TestExchangeRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+		claims   jwt.MapClaims
+	}{
+		{name: "verifier PKCE salah", verifier: "lain", nonce: "nonce-1"},
+		{name: "nonce tidak cocok", verifier: "verifier-1", nonce: "nonce-lain"},
+		{name: "audience lain", verifier: "verifier-1", nonce: "nonce-1", claims: jwt.MapClaims{"aud": "app-lain"}},
+		{name: "issuer lain", verifier: "verifier-1", nonce: "nonce-1", claims: jwt.MapClaims{"iss": "https://evil.example"}},
+		{name: "kedaluwarsa", verifier: "verifier-1", nonce: "nonce-1", claims: jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}},
+		{name: "tanpa sub", verifier: "verifier-1", nonce: "nonce-1", claims: jwt.MapClaims{"sub": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockProvider(t)
+			m.claims = tt.claims
+			c := newTestClient(m)
+			ctx := context.Background()
+
+			authURL, err := c.AuthCodeURL(ctx, "st", "nonce-1", "verifier-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := m.authorize(authURL)
+			if _, err := c.Exchange(ctx, code, tt.verifier, tt.nonce); err == nil {
+				t.Error("seharusnya ditolak")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsForeignKey(t *testing.T) {
+	m := newMockProvider(t)
+	c := newTestClient(m)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": m.srv.URL, "aud": "alumni-api", "sub": "x", "nonce": "n",
+		"iat": time.Now().Unix(), "exp": time.Now().Add(time.Minute).Unix(),
+	})
+	tok.Header["kid"] = m.kid
+	raw, _ := tok.SignedString(other)
+
+	if _, err := c.Verify(context.Background(), raw, "n"); err == nil {
+		t.Error("token dengan tanda tangan key lain seharusnya ditolak")
+	}
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	m := newMockProvider(t)
+	m.issuer = "https://evil.example"
+	c := newTestClient(m)
+	if _, err := c.Discover(context.Background()); err == nil {
+		t.Error("issuer yang tidak cocok seharusnya ditolak")
+	}
+}
@@ -0,0 +1,155 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims adalah isi ID token yang sudah diverifikasi
+type Claims map[string]interface{}
+
+// String mengambil claim string; kosong jika tidak ada atau bukan string
+func (c Claims) String(name string) string {
+	v, _ := c[name].(string)
+	return v
+}
+
+// Bool mengambil claim boolean (beberapa provider mengirim "true" sebagai string)
+func (c Claims) Bool(name string) bool {
+	switch v := c[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+func (c Claims) Subject() string { return c.String("sub") }
+
+// Verify memeriksa tanda tangan, issuer, audience, masa berlaku, dan nonce ID token
+func (c *Client) Verify(ctx context.Context, rawIDToken, nonce string) (Claims, error) {
+	if _, err := c.Discover(ctx); err != nil {
+		return nil, err
+	}
+	mc := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, mc, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.keys.get(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(c.Config.Issuer),
+		jwt.WithAudience(c.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("id_token tidak valid: %w", err)
+	}
+	claims := Claims(mc)
+	if claims.String("nonce") != nonce {
+		return nil, errors.New("id_token tidak valid: nonce tidak cocok")
+	}
+	if claims.Subject() == "" {
+		return nil, errors.New("id_token tidak valid: sub kosong")
+	}
+	return claims, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// keyCache menyimpan JWKS provider; diambil ulang saat ada kid yang belum dikenal
+// (rotasi key di sisi provider), paling sering sekali per menit.
+type keyCache struct {
+	client *Client
+	uri    string
+
+	mu      sync.Mutex
+	keys    map[string]interface{}
+	fetched time.Time
+}
+
+func (k *keyCache) get(ctx context.Context, kid string) (interface{}, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if key, ok := k.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(k.fetched) < time.Minute {
+		return nil, fmt.Errorf("kid %q tidak dikenal", kid)
+	}
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := k.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("kid %q tidak dikenal", kid)
+}
+
+func (k *keyCache) refresh(ctx context.Context) error {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := k.client.getJSON(ctx, k.uri, &set); err != nil {
+		return fmt.Errorf("gagal mengambil JWKS: %w", err)
+	}
+	keys := map[string]interface{}{}
+	for _, j := range set.Keys {
+		key, err := j.publicKey()
+		if err != nil {
+			continue // key dengan tipe yang tidak didukung dilewati
+		}
+		keys[j.Kid] = key
+	}
+	k.keys, k.fetched = keys, time.Now()
+	return nil
+}
+
+func (j jwk) publicKey() (interface{}, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if j.Crv != "P-256" {
+			return nil, fmt.Errorf("kurva %s tidak didukung", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("kty %s tidak didukung", j.Kty)
+}
@@ -1,36 +1,44 @@
 package route
 
 import (
-	"context"
-	"time"
+	"errors"
+	"strconv"
 
+	"go_clean/app/apperror"
 	"go_clean/app/models"
-	"go_clean/app/repository"
 	"go_clean/app/service"
+	"go_clean/helper"
 	"go_clean/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupAlumniMongoRoutes(app *fiber.App, mongoDB *mongo.Database) {
-	// 🔧 Inisialisasi repository & service
-	repo := repository.NewAlumniMongoRepository(mongoDB)
-	svc := service.NewAlumniMongoService(repo)
+// validAlumniMongoID: id boleh ObjectID hex atau alumni_id numerik
+func validAlumniMongoID(id string) bool {
+	if _, err := primitive.ObjectIDFromHex(id); err == nil {
+		return true
+	}
+	_, err := strconv.Atoi(id)
+	return err == nil
+}
 
-	// 🧩 Semua endpoint butuh login (AuthRequired)
-	api := app.Group("/api/alumni-mongo", middleware.AuthRequired())
+// SetupAlumniMongoRoutes memasang /alumni-mongo di bawah auth (group yang sudah
+// memakai AuthRequired); jangan pasang AuthRequired lagi di sini supaya
+// autentikasi tidak berjalan dua kali per request
+func SetupAlumniMongoRoutes(auth fiber.Router, svc *service.AlumniMongoService) {
+	api := auth.Group("/alumni-mongo")
 
 	// ========== READ (bisa diakses semua user login) ==========
 
 	// GET /api/alumni-mongo → Ambil semua data alumni
 	api.Get("/", func(c *fiber.Ctx) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		data, err := svc.GetAll(ctx)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "alumni_fetch_failed")
 		}
 		return c.JSON(data)
 	})
@@ -38,71 +46,85 @@ func SetupAlumniMongoRoutes(app *fiber.App, mongoDB *mongo.Database) {
 	// GET /api/alumni-mongo/:id → Ambil 1 data alumni by ID
 	api.Get("/:id", func(c *fiber.Ctx) error {
 		id := c.Params("id")
+		if !validAlumniMongoID(id) {
+			return apperror.Invalid("invalid_alumni_id")
+		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		data, err := svc.GetByID(ctx, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperror.NotFound("alumni_not_found")
+		}
 		if err != nil {
-			return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "alumni_fetch_failed")
 		}
 		return c.JSON(data)
 	})
 
-	// ========== ADMIN ONLY (CREATE, UPDATE, DELETE) ==========
+	// ========== BUTUH alumni:write (CREATE, UPDATE, DELETE) ==========
 
-	admin := api.Group("", middleware.AdminOnly())
+	admin := api.Group("", middleware.Require(models.PermAlumniWrite))
 
-	// POST /api/alumni-mongo → Tambah data (hanya admin)
+	// POST /api/alumni-mongo → Tambah data (butuh alumni:write)
 	admin.Post("/", func(c *fiber.Ctx) error {
 		var input models.AlumniMongo
 		if err := c.BodyParser(&input); err != nil {
-			return c.Status(400).JSON(fiber.Map{"error": "JSON tidak valid"})
+			return apperror.InvalidPayload()
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		data, err := svc.Create(ctx, &input)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "alumni_create_failed")
 		}
 
 		return c.Status(201).JSON(data)
 	})
 
-	// PUT /api/alumni-mongo/:id → Update data (hanya admin)
+	// PUT /api/alumni-mongo/:id → Update data (butuh alumni:write)
 	admin.Put("/:id", func(c *fiber.Ctx) error {
 		id := c.Params("id")
+		if !validAlumniMongoID(id) {
+			return apperror.Invalid("invalid_alumni_id")
+		}
 		var input models.AlumniMongo
 
 		if err := c.BodyParser(&input); err != nil {
-			return c.Status(400).JSON(fiber.Map{"error": "JSON tidak valid"})
+			return apperror.InvalidPayload()
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		data, err := svc.Update(ctx, id, &input)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperror.NotFound("alumni_not_found")
+		}
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "alumni_update_failed")
 		}
 
 		return c.JSON(data)
 	})
 
-	// DELETE /api/alumni-mongo/:id → Hapus data (hanya admin)
+	// DELETE /api/alumni-mongo/:id → Hapus data (butuh alumni:write)
 	admin.Delete("/:id", func(c *fiber.Ctx) error {
 		id := c.Params("id")
+		if !validAlumniMongoID(id) {
+			return apperror.Invalid("invalid_alumni_id")
+		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		err := svc.Delete(ctx, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperror.NotFound("alumni_not_found")
+		}
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "alumni_delete_failed")
 		}
 
-		return c.JSON(fiber.Map{"message": "Data alumni berhasil dihapus"})
+		return c.JSON(fiber.Map{"message": helper.Message(c, "alumni_deleted")})
 	})
 }
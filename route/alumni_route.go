@@ -0,0 +1,26 @@
+package route
+
+import (
+	"go_clean/app/handlers"
+	"go_clean/app/models"
+	"go_clean/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAlumniRoutes memasang /alumni di bawah auth (group yang sudah memakai AuthRequired)
+func SetupAlumniRoutes(auth fiber.Router, h *handlers.AlumniHandler) {
+	alumni := auth.Group("/alumni")
+	alumni.Get("/", h.GetAll)
+	alumni.Get("/:id", h.GetByID)
+	alumni.Get("/angkatan/:angkatan", h.GetByAngkatan)
+	alumni.Get("/alumni-pag", h.List)
+	alumni.Get("/with-pekerjaan/:nim", h.GetWithPekerjaan)
+
+	// dipasang per route, bukan lewat Group("", ...): middleware group dicocokkan
+	// dengan prefix string biasa, sehingga ikut mengenai /api/alumni-mongo
+	alumniWrite := middleware.Require(models.PermAlumniWrite)
+	alumni.Post("/", alumniWrite, h.Create)
+	alumni.Put("/:id", alumniWrite, h.Update)
+	alumni.Delete("/:id", alumniWrite, h.Delete)
+}
@@ -0,0 +1,373 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_clean/app/handlers"
+	"go_clean/app/models"
+	"go_clean/app/policy"
+	"go_clean/app/repository/memory"
+	"go_clean/app/service"
+	"go_clean/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test di file ini hanya mencakup route alumni & pekerjaan (Postgres dan
+// Mongo), dijalankan di atas repository in-memory tanpa database. Route lain
+// (login, users, roles, klaim, ...) butuh repository yang tidak punya versi
+// in-memory, jadi tidak dipasang di sini; SetupRoutes tidak dipakai.
+
+// fakeTokens memetakan string token langsung ke claims-nya
+type fakeTokens map[string]*models.JWTClaims
+
+func (f fakeTokens) ValidateToken(tokenStr string, purposes ...string) (*models.JWTClaims, error) {
+	if c, ok := f[tokenStr]; ok && slices.Contains(purposes, c.Purpose) {
+		return c, nil
+	}
+	return nil, errors.New("token tidak dikenal")
+}
+
+// allSessionsActive menganggap semua sesi aktif dan menghitung pengecekannya
+type allSessionsActive struct{ checks atomic.Int32 }
+
+func (s *allSessionsActive) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	s.checks.Add(1)
+	return true, nil
+}
+
+// fakeUsers memenuhi policy.UserFinder
+type fakeUsers map[int]*models.User
+
+func (f fakeUsers) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	if u, ok := f[id]; ok {
+		return u, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+type testAPI struct {
+	t        *testing.T
+	app      *fiber.App
+	users    fakeUsers
+	sessions *allSessionsActive
+	// lang dikirim sebagai Accept-Language jika tidak kosong
+	lang string
+}
+
+const (
+	adminToken  = "admin"
+	ownerToken  = "owner"
+	readerToken = "reader"
+	// englishToken milik user yang memilih bahasa Inggris di profilnya
+	englishToken = "reader-en"
+)
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	db := memory.New()
+	users := fakeUsers{
+		1: {ID: 1, Username: "admin", Role: "admin", EmailVerified: true},
+		2: {ID: 2, Username: "budi", Role: "user", EmailVerified: true}, // AlumniID diisi per test
+		3: {ID: 3, Username: "tamu", Role: "user", EmailVerified: true},
+	}
+	tokens := fakeTokens{
+		adminToken: {UserID: 1, Username: "admin", Role: "admin", SessionID: "s1", EmailVerified: true, Permissions: []string{
+			models.PermAlumniWrite, models.PermPekerjaanWrite, models.PermPekerjaanHardDelete, models.PermPekerjaanTrashAll,
+		}},
+		ownerToken:   {UserID: 2, Username: "budi", Role: "user", SessionID: "s2", EmailVerified: true},
+		readerToken:  {UserID: 3, Username: "tamu", Role: "user", SessionID: "s3", EmailVerified: true},
+		englishToken: {UserID: 3, Username: "tamu", Role: "user", SessionID: "s4", EmailVerified: true, Locale: "en"},
+	}
+
+	authz := &policy.Authorizer{Users: users}
+	alumniService := &service.AlumniService{Repo: db.Alumni(), Tx: db}
+	pekerjaanService := &service.PekerjaanService{Repo: db.Pekerjaan(), Tx: db}
+
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	sessions := &allSessionsActive{}
+	authRequired := middleware.AuthRequired(tokens, sessions, nil, nil)
+	api := app.Group("/api")
+	auth := api.Group("", authRequired, middleware.VerifiedEmailForWrites())
+	SetupAlumniRoutes(auth, &handlers.AlumniHandler{Svc: alumniService})
+	SetupPekerjaanRoutes(api, auth, &handlers.PekerjaanHandler{Svc: pekerjaanService, Authz: authz})
+	SetupPekerjaanMongoRoutes(auth, service.NewPekerjaanMongoService(memory.NewPekerjaanMongoStore()))
+	SetupAlumniMongoRoutes(auth, service.NewAlumniMongoService(memory.NewAlumniMongoStore()))
+
+	return &testAPI{t: t, app: app, users: users, sessions: sessions}
+}
+
+// do mengirim request dan mengembalikan status serta body JSON
+func (a *testAPI) do(method, path, token string, body any) (int, map[string]any) {
+	a.t.Helper()
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			a.t.Fatal(err)
+		}
+		r = bytes.NewReader(b)
+	}
+	req := httptest.NewRequest(method, path, r)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if a.lang != "" {
+		req.Header.Set("Accept-Language", a.lang)
+	}
+	resp, err := a.app.Test(req, -1)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var out map[string]any
+	raw, _ := io.ReadAll(resp.Body)
+	if len(raw) > 0 && raw[0] == '{' {
+		if err := json.Unmarshal(raw, &out); err != nil {
+			a.t.Fatalf("%s %s: body bukan JSON: %s", method, path, raw)
+		}
+	}
+	return resp.StatusCode, out
+}
+
+// expect memastikan status respons, lalu mengembalikan body
+func (a *testAPI) expect(want int, method, path, token string, body any) map[string]any {
+	a.t.Helper()
+	got, out := a.do(method, path, token, body)
+	if got != want {
+		a.t.Fatalf("%s %s: status = %d, want %d (body %v)", method, path, got, want, out)
+	}
+	return out
+}
+
+func dataID(t *testing.T, body map[string]any) int {
+	t.Helper()
+	data, _ := body["data"].(map[string]any)
+	id, ok := data["id"].(float64)
+	if !ok {
+		t.Fatalf("respons tanpa data.id: %v", body)
+	}
+	return int(id)
+}
+
+func newAlumni(nim, email string) map[string]any {
+	return map[string]any{
+		"nim": nim, "nama": "Budi " + nim, "jurusan": "Informatika",
+		"angkatan": 2019, "tahun_lulus": 2023, "email": email,
+	}
+}
+
+func TestAlumniAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	api.expect(http.StatusUnauthorized, "GET", "/api/alumni", "", nil)
+	api.expect(http.StatusForbidden, "POST", "/api/alumni", readerToken, newAlumni("1", "a@x.id"))
+	invalid := api.expect(http.StatusBadRequest, "POST", "/api/alumni", adminToken, map[string]any{"nim": "1"})
+	if e := invalid["error"].(map[string]any); e["code"] != "validation_failed" || len(e["fields"].([]any)) != 3 {
+		t.Fatalf("error validasi = %v, want validation_failed dengan 3 field", e)
+	}
+
+	id := dataID(t, api.expect(http.StatusCreated, "POST", "/api/alumni", adminToken, newAlumni("214110001", "budi@x.id")))
+	api.expect(http.StatusCreated, "POST", "/api/alumni", adminToken, newAlumni("214110002", "ani@x.id"))
+	// NIM unik, seperti constraint di Postgres
+	api.expect(http.StatusConflict, "POST", "/api/alumni", adminToken, newAlumni("214110001", "lain@x.id"))
+
+	got := api.expect(http.StatusOK, "GET", "/api/alumni/"+strconv.Itoa(id), readerToken, nil)
+	if data := got["data"].(map[string]any); data["nim"] != "214110001" {
+		t.Fatalf("nim = %v", data["nim"])
+	}
+	list := api.expect(http.StatusOK, "GET", "/api/alumni", readerToken, nil)
+	if n := len(list["data"].([]any)); n != 2 {
+		t.Fatalf("jumlah alumni = %d, want 2", n)
+	}
+	angkatan := api.expect(http.StatusOK, "GET", "/api/alumni/angkatan/2019", readerToken, nil)
+	if data := angkatan["data"].(map[string]any); data["jumlah"] != float64(2) {
+		t.Fatalf("jumlah angkatan 2019 = %v, want 2", data["jumlah"])
+	}
+
+	upd := newAlumni("214110001", "budi@x.id")
+	upd["nama"] = "Budi Santoso"
+	got = api.expect(http.StatusOK, "PUT", "/api/alumni/"+strconv.Itoa(id), adminToken, upd)
+	if data := got["data"].(map[string]any); data["nama"] != "Budi Santoso" {
+		t.Fatalf("nama setelah update = %v", data["nama"])
+	}
+	api.expect(http.StatusNotFound, "PUT", "/api/alumni/999", adminToken, upd)
+
+	api.expect(http.StatusOK, "DELETE", "/api/alumni/"+strconv.Itoa(id), adminToken, nil)
+	api.expect(http.StatusNotFound, "GET", "/api/alumni/"+strconv.Itoa(id), readerToken, nil)
+}
+
+func TestPekerjaanAPI(t *testing.T) {
+	api := newTestAPI(t)
+	alumniID := dataID(t, api.expect(http.StatusCreated, "POST", "/api/alumni", adminToken, newAlumni("214110001", "budi@x.id")))
+	api.users[2].AlumniID = &alumniID
+
+	job := map[string]any{
+		"alumni_id": alumniID, "nama_perusahaan": "PT Maju", "posisi_jabatan": "Backend Engineer",
+		"bidang_industri": "Teknologi", "lokasi_kerja": "Surabaya", "status_pekerjaan": "aktif",
+		"tanggal_mulai_kerja": time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// hanya pekerjaan:write yang boleh menambah
+	api.expect(http.StatusForbidden, "POST", "/api/pekerjaan", ownerToken, job)
+	id := dataID(t, api.expect(http.StatusCreated, "POST", "/api/pekerjaan", adminToken, job))
+
+	// alumni_id yang tidak ada ditolak (foreign key), dan tidak meninggalkan baris
+	orphan := map[string]any{"alumni_id": 999, "nama_perusahaan": "PT Hantu", "posisi_jabatan": "-"}
+	api.expect(http.StatusBadRequest, "POST", "/api/pekerjaan", adminToken, orphan)
+
+	// pemilik boleh mengubah pekerjaannya sendiri, user lain tidak
+	job["posisi_jabatan"] = "Tech Lead"
+	got := api.expect(http.StatusOK, "PUT", "/api/pekerjaan/"+strconv.Itoa(id), ownerToken, job)
+	if data := got["data"].(map[string]any); data["posisi_jabatan"] != "Tech Lead" {
+		t.Fatalf("posisi setelah update = %v", data["posisi_jabatan"])
+	}
+	api.expect(http.StatusForbidden, "PUT", "/api/pekerjaan/"+strconv.Itoa(id), readerToken, job)
+	api.expect(http.StatusNotFound, "PUT", "/api/pekerjaan/999", adminToken, job)
+
+	// pagination cukup login, tanpa pekerjaan:write
+	page := api.expect(http.StatusOK, "GET", "/api/pekerjaan-pag?search=maju&limit=5", readerToken, nil)
+	if meta := page["meta"].(map[string]any); meta["total"] != float64(1) {
+		t.Fatalf("meta.total = %v, want 1", meta["total"])
+	}
+
+	// soft delete -> trash -> restore -> soft delete -> hard delete
+	api.expect(http.StatusForbidden, "DELETE", "/api/pekerjaan/"+strconv.Itoa(id), readerToken, nil)
+	api.expect(http.StatusOK, "DELETE", "/api/pekerjaan/"+strconv.Itoa(id), ownerToken, nil)
+	if all := api.expect(http.StatusOK, "GET", "/api/pekerjaan", readerToken, nil); all["data"] != nil {
+		t.Fatalf("pekerjaan di trash masih muncul: %v", all["data"])
+	}
+	trash := api.expect(http.StatusOK, "GET", "/api/pekerjaan/trash", ownerToken, nil)
+	if n := len(trash["data"].([]any)); n != 1 {
+		t.Fatalf("trash pemilik berisi %d, want 1", n)
+	}
+	if trash := api.expect(http.StatusOK, "GET", "/api/pekerjaan/trash", readerToken, nil); len(trash["data"].([]any)) != 0 {
+		t.Fatalf("trash user lain tidak kosong: %v", trash["data"])
+	}
+	api.expect(http.StatusOK, "PUT", "/api/pekerjaan/restore/"+strconv.Itoa(id), ownerToken, nil)
+	api.expect(http.StatusOK, "GET", "/api/pekerjaan/"+strconv.Itoa(id), readerToken, nil)
+
+	api.expect(http.StatusOK, "DELETE", "/api/pekerjaan/"+strconv.Itoa(id), adminToken, nil)
+	api.expect(http.StatusOK, "DELETE", "/api/pekerjaan/hard-delete/"+strconv.Itoa(id), adminToken, nil)
+	api.expect(http.StatusNotFound, "GET", "/api/pekerjaan/"+strconv.Itoa(id), readerToken, nil)
+}
+
+func TestMongoAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	api.expect(http.StatusForbidden, "POST", "/api/alumni-mongo", readerToken, newAlumni("1", "a@x.id"))
+	created := api.expect(http.StatusCreated, "POST", "/api/alumni-mongo", adminToken, newAlumni("214110001", "budi@x.id"))
+	id, _ := created["id"].(string)
+	if id == "" {
+		t.Fatalf("alumni-mongo tanpa id: %v", created)
+	}
+	api.expect(http.StatusOK, "GET", "/api/alumni-mongo/"+id, readerToken, nil)
+	upd := newAlumni("214110001", "budi@x.id")
+	upd["nama"] = "Budi Santoso"
+	api.expect(http.StatusOK, "PUT", "/api/alumni-mongo/"+id, adminToken, upd)
+	if got := api.expect(http.StatusOK, "GET", "/api/alumni-mongo/"+id, readerToken, nil); got["nama"] != "Budi Santoso" {
+		t.Fatalf("nama setelah update = %v", got["nama"])
+	}
+	api.expect(http.StatusOK, "DELETE", "/api/alumni-mongo/"+id, adminToken, nil)
+	api.expect(http.StatusNotFound, "GET", "/api/alumni-mongo/"+id, readerToken, nil)
+	api.expect(http.StatusBadRequest, "GET", "/api/alumni-mongo/bukan-id", readerToken, nil)
+	api.expect(http.StatusNotFound, "PUT", "/api/alumni-mongo/"+id, adminToken, upd)
+	api.expect(http.StatusNotFound, "DELETE", "/api/alumni-mongo/"+id, adminToken, nil)
+	api.expect(http.StatusBadRequest, "DELETE", "/api/alumni-mongo/bukan-id", adminToken, nil)
+
+	// alumni_id numerik dipakai sebagai id alternatif
+	sari := newAlumni("214110002", "sari@x.id")
+	sari["alumni_id"] = 42
+	api.expect(http.StatusCreated, "POST", "/api/alumni-mongo", adminToken, sari)
+	sari["nama"] = "Sari Dewi"
+	if got := api.expect(http.StatusOK, "PUT", "/api/alumni-mongo/42", adminToken, sari); got["nama"] != "Sari Dewi" {
+		t.Fatalf("nama setelah update lewat alumni_id = %v", got["nama"])
+	}
+	api.expect(http.StatusOK, "GET", "/api/alumni-mongo/42", readerToken, nil)
+	api.expect(http.StatusOK, "DELETE", "/api/alumni-mongo/42", adminToken, nil)
+	api.expect(http.StatusNotFound, "GET", "/api/alumni-mongo/42", readerToken, nil)
+
+	job := map[string]any{"alumni_id": 7, "nama_perusahaan": "PT Maju", "posisi_jabatan": "Analis"}
+	created = api.expect(http.StatusCreated, "POST", "/api/pekerjaan-mongo", adminToken, job)
+	pid, _ := created["id"].(string)
+	api.expect(http.StatusOK, "GET", "/api/pekerjaan-mongo/"+pid, readerToken, nil)
+	job["posisi_jabatan"] = "Manajer"
+	if got := api.expect(http.StatusOK, "PUT", "/api/pekerjaan-mongo/"+pid, adminToken, job); got["posisi_jabatan"] != "Manajer" {
+		t.Fatalf("posisi setelah update = %v", got["posisi_jabatan"])
+	}
+	api.expect(http.StatusOK, "DELETE", "/api/pekerjaan-mongo/"+pid, adminToken, nil)
+	api.expect(http.StatusNotFound, "GET", "/api/pekerjaan-mongo/"+pid, readerToken, nil)
+	api.expect(http.StatusBadRequest, "GET", "/api/pekerjaan-mongo/bukan-id", readerToken, nil)
+	api.expect(http.StatusNotFound, "PUT", "/api/pekerjaan-mongo/"+pid, adminToken, job)
+	api.expect(http.StatusNotFound, "DELETE", "/api/pekerjaan-mongo/"+pid, adminToken, nil)
+	api.expect(http.StatusBadRequest, "DELETE", "/api/pekerjaan-mongo/bukan-id", adminToken, nil)
+}
+
+// TestAuthRunsOnce memastikan route yang dipasang di bawah group auth tidak
+// memasang AuthRequired lagi (sesi dicek dua kali, audit tercatat ganda)
+func TestAuthRunsOnce(t *testing.T) {
+	api := newTestAPI(t)
+	for _, path := range []string{"/api/alumni", "/api/pekerjaan", "/api/alumni-mongo", "/api/pekerjaan-mongo"} {
+		api.sessions.checks.Store(0)
+		api.expect(http.StatusOK, "GET", path, readerToken, nil)
+		if n := api.sessions.checks.Load(); n != 1 {
+			t.Errorf("GET %s: sesi dicek %d kali, want 1", path, n)
+		}
+	}
+}
+
+func errorMessage(t *testing.T, body map[string]any) string {
+	t.Helper()
+	e, ok := body["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("respons tanpa error: %v", body)
+	}
+	return e["message"].(string)
+}
+
+func TestLocalizedMessages(t *testing.T) {
+	api := newTestAPI(t)
+	api.expect(http.StatusCreated, "POST", "/api/alumni", adminToken, newAlumni("214110001", "budi@x.id"))
+
+	// tanpa Accept-Language memakai bahasa Indonesia
+	if msg := errorMessage(t, api.expect(http.StatusNotFound, "GET", "/api/alumni/999", readerToken, nil)); msg != "Alumni tidak ditemukan" {
+		t.Fatalf("pesan default = %q", msg)
+	}
+
+	api.lang = "en-US,en;q=0.9,id;q=0.5"
+	if msg := errorMessage(t, api.expect(http.StatusNotFound, "GET", "/api/alumni/999", readerToken, nil)); msg != "Alumni not found" {
+		t.Fatalf("pesan en = %q", msg)
+	}
+	if got := api.expect(http.StatusOK, "GET", "/api/alumni", readerToken, nil); got["message"] != "Alumni data retrieved successfully" {
+		t.Fatalf("pesan sukses en = %v", got["message"])
+	}
+	invalid := api.expect(http.StatusBadRequest, "POST", "/api/alumni", adminToken, map[string]any{"nim": "1"})
+	fields := invalid["error"].(map[string]any)["fields"].([]any)
+	if f := fields[0].(map[string]any); f["message"] != "nama is required" {
+		t.Fatalf("pesan field en = %v", f["message"])
+	}
+	// bahasa yang tidak didukung jatuh ke default
+	api.lang = "fr-FR, de;q=0.8"
+	if msg := errorMessage(t, api.expect(http.StatusNotFound, "GET", "/api/alumni/999", readerToken, nil)); msg != "Alumni tidak ditemukan" {
+		t.Fatalf("pesan fallback = %q", msg)
+	}
+
+	// preferensi user di token mengalahkan Accept-Language
+	api.lang = "id"
+	if msg := errorMessage(t, api.expect(http.StatusNotFound, "GET", "/api/alumni/999", englishToken, nil)); msg != "Alumni not found" {
+		t.Fatalf("pesan preferensi user = %q", msg)
+	}
+}
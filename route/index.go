@@ -1,30 +1,26 @@
 package route
 
 import (
-	"database/sql"
 	"go_clean/app/handlers"
-	"go_clean/app/repository"
-	"go_clean/app/service"
+	"go_clean/app/models"
+	"go_clean/container"
 	"go_clean/middleware"
 
 	"github.com/gofiber/fiber/v2"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database) {
-	// =======================
-	// REPOSITORIES (Postgres)
-	// =======================
-	alumniRepo := &repository.AlumniRepository{DB: db}
-	pekerjaanRepo := &repository.PekerjaanRepository{DB: db}
-	userRepo := &repository.UserRepository{DB: db}
+// SetupRoutes memasang semua route di app memakai service dari container
+func SetupRoutes(app *fiber.App, deps *container.Container) {
+	repos, svc := deps.Repos, deps.Services
+	alumniService, pekerjaanService, authz := svc.Alumni, svc.Pekerjaan, svc.Authz
+	userService, roleService, meService := svc.Users, svc.Roles, svc.Me
+	passwordService, verificationService := svc.Passwords, svc.Verification
+	claimService, lockoutService, mfaService := svc.Claims, svc.Lockout, svc.MFA
+	oidcService, apiKeyService, authService := svc.OIDC, svc.APIKeys, svc.Auth
 
-	// =======================
-	// SERVICES
-	// =======================
-	alumniService := &service.AlumniService{Repo: alumniRepo}
-	pekerjaanService := &service.PekerjaanService{Repo: pekerjaanRepo}
-	userService := &service.UserService{Repo: userRepo}
+	userHandler := &handlers.UserHandler{Svc: userService}
+
+	authRequired := middleware.AuthRequired(deps.JWT, repos.Sessions, repos.APIKeys, repos.Audit)
 
 	// =======================
 	// ROOT
@@ -32,54 +28,108 @@ func SetupRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database) {
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.SendString("Welcome to the Alumni Management API 🚀")
 	})
+	app.Get("/.well-known/jwks.json", handlers.JWKS(deps.JWT))
 
 	// =======================
 	// PUBLIC
 	// =======================
 	api := app.Group("/api")
-	api.Post("/login", handlers.Login)
-	api.Post("/register", userService.RegisterUser)
+	api.Post("/login", authService.Login)
+	api.Post("/login/2fa", mfaService.LoginVerify)
+	api.Post("/login/2fa/setup", mfaService.LoginSetup)
+	api.Post("/register", userHandler.Register)
+	api.Get("/oidc/login", oidcService.Login)
+	api.Get("/oidc/callback", oidcService.Callback)
+	api.Post("/token/refresh", authService.RefreshToken)
+	api.Post("/password/forgot", passwordService.ForgotPassword)
+	api.Post("/password/reset", passwordService.ResetPassword)
+	api.Post("/email/verify", verificationService.Verify)
+
+	// endpoint khusus pemilik akun, tidak untuk API key maupun impersonasi
+	sessionOnly := middleware.SessionOnly()
+
+	// =======================
+	// PROTECTED, BOLEH SEBELUM EMAIL TERVERIFIKASI
+	// didaftarkan sebelum group auth supaya tidak melewati VerifiedEmailForWrites
+	// =======================
+	api.Post("/logout", authRequired, authService.Logout) // juga mengakhiri impersonasi
+	api.Post("/email/verification", authRequired, sessionOnly, verificationService.Resend)
+	api.Put("/me/email", authRequired, meService.UpdateEmail) // untuk membetulkan email yang salah ketik
 
 	// =======================
 	// PROTECTED
 	// =======================
-	auth := api.Group("", middleware.AuthRequired())
+	// akun yang emailnya belum terverifikasi hanya bisa membaca
+	auth := api.Group("", authRequired, middleware.VerifiedEmailForWrites())
 
-	auth.Post("/register-admin", middleware.AdminOnly(), userService.AdminCreateUser)
-	auth.Get("/profile", handlers.Profile)
+	auth.Post("/2fa/setup", sessionOnly, mfaService.Setup)
+	auth.Post("/2fa/confirm", sessionOnly, mfaService.Confirm)
+	auth.Post("/2fa/disable", sessionOnly, mfaService.Disable)
+	auth.Post("/2fa/recovery-codes", sessionOnly, mfaService.RegenerateRecoveryCodes)
+	// penautan SSO ke akun lokal harus dikonfirmasi pemilik akun
+	auth.Get("/oidc/link", sessionOnly, oidcService.StartLink)
+	auth.Post("/oidc/link/confirm", sessionOnly, oidcService.ConfirmLink)
+	usersManage := middleware.Require(models.PermUsersManage)
+	auth.Post("/register-admin", usersManage, userHandler.AdminCreate)
 
 	// =======================
-	// ALUMNI ROUTES (Postgres)
+	// USER ADMINISTRATION
+	// =======================
+	users := auth.Group("/users", usersManage)
+	users.Get("/", userHandler.List)
+	users.Get("/:id", userHandler.Get)
+	users.Put("/:id/role", userHandler.UpdateRole)
+	users.Put("/:id/alumni", userHandler.LinkAlumni)
+	users.Post("/:id/disable", userHandler.Disable)
+	users.Post("/:id/enable", userHandler.Enable)
+	users.Post("/:id/force-password-reset", userHandler.ForcePasswordReset)
+	users.Post("/:id/unlock", lockoutService.UnlockUser)
+	users.Post("/:id/impersonate", sessionOnly, middleware.Require(models.PermUsersImpersonate), userHandler.Impersonate)
+	users.Delete("/:id", userHandler.Delete)
+
+	// =======================
+	// KLAIM AKUN ALUMNI
 	// =======================
-	alumni := auth.Group("/alumni")
-	alumni.Get("/", alumniService.GetAllAlumni)
-	alumni.Get("/:id", alumniService.GetAlumniByID)
-	alumni.Get("/angkatan/:angkatan", alumniService.GetAlumniByAngkatan)
-	alumni.Get("/alumni-pag", handlers.GetAlumniListHandler)
-	alumni.Get("/with-pekerjaan/:nim", alumniService.GetAlumniAndPekerjaan)
+	auth.Get("/claims", claimService.GetMyClaims)
+	auth.Post("/claims", claimService.CreateClaim)
+	auth.Post("/claims/:id/verify", claimService.VerifyClaim)
+	auth.Post("/claims/:id/dispute", claimService.DisputeClaim)
+
+	claimAdmin := auth.Group("/admin/claims", usersManage)
+	claimAdmin.Get("/", claimService.GetClaimQueue)
+	claimAdmin.Post("/:id/approve", claimService.ApproveClaim)
+	claimAdmin.Post("/:id/reject", claimService.RejectClaim)
 
-	alumniAdmin := alumni.Group("", middleware.AdminOnly())
-	alumniAdmin.Post("/", alumniService.CreateAlumni)
-	alumniAdmin.Put("/:id", alumniService.UpdateAlumni)
-	alumniAdmin.Delete("/:id", alumniService.DeleteAlumni)
+	// =======================
+	// ROLES & PERMISSIONS
+	// =======================
+	auth.Get("/roles", usersManage, roleService.GetAllRoles)
+	auth.Put("/roles/:name", usersManage, roleService.SaveRole)
+	auth.Get("/permissions", usersManage, roleService.GetAllPermissions)
+	auth.Get("/profile", handlers.Profile)
+	auth.Get("/me", meService.GetMe)
+	auth.Put("/me", meService.UpdateMe)
+	auth.Put("/me/password", sessionOnly, meService.ChangePassword)
+
+	// =======================
+	// API KEYS (integrasi antar sistem)
+	// =======================
+	apiKeys := auth.Group("/api-keys", sessionOnly, usersManage)
+	apiKeys.Get("/", apiKeyService.GetAPIKeys)
+	apiKeys.Post("/", apiKeyService.CreateAPIKey)
+	apiKeys.Delete("/:id", apiKeyService.RevokeAPIKey)
 
 	// =======================
-	// PEKERJAAN ROUTES (Postgres)
+	// ALUMNI & PEKERJAAN ROUTES (Postgres)
 	// =======================
-	pkj := auth.Group("/pekerjaan")
-	pkj.Get("/trash", pekerjaanService.TrashAllPekerjaan)
-	pkj.Get("/", pekerjaanService.GetAllPekerjaan)
-	pkj.Get("/:id", pekerjaanService.GetPekerjaanByID)
-	pkj.Get("/alumni/:alumni_id", pekerjaanService.GetPekerjaanByAlumniID)
-	pkj.Put("/:id", pekerjaanService.UpdatePekerjaan)
-	pkj.Put("/restore/:id", pekerjaanService.RestorePekerjaan)
-	pkj.Delete("/:id", pekerjaanService.DeletePekerjaan)
-	pkj.Delete("/hard-delete/:id", pekerjaanService.HardDeletePekerjaan)
-	pkjAdmin := pkj.Group("", middleware.AdminOnly())
-	pkjAdmin.Post("/", pekerjaanService.CreatePekerjaan)
+	SetupAlumniRoutes(auth, &handlers.AlumniHandler{Svc: alumniService})
+	SetupPekerjaanRoutes(api, auth, &handlers.PekerjaanHandler{Svc: pekerjaanService, Authz: authz})
 
 	// =======================
-	// PAGINATION
+	// MONGO ROUTES
 	// =======================
-	api.Get("/pekerjaan-pag", handlers.GetPekerjaanListHandler)
+	if svc.AlumniMongo != nil {
+		SetupPekerjaanMongoRoutes(auth, svc.PekerjaanMongo)
+		SetupAlumniMongoRoutes(auth, svc.AlumniMongo)
+	}
 }
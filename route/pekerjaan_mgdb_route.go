@@ -1,111 +1,123 @@
 package route
 
 import (
-	"context"
+	"errors"
 	"strconv"
-	"time"
 
+	"go_clean/app/apperror"
 	"go_clean/app/models"
-	"go_clean/app/repository"
 	"go_clean/app/service"
+	"go_clean/helper"
 	"go_clean/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupPekerjaanMongoRoutes(app *fiber.App, mongoDB *mongo.Database) {
-	repo := repository.NewPekerjaanMongoRepository(mongoDB)
-	svc := service.NewPekerjaanMongoService(repo)
-
-	// Semua endpoint butuh login
-	api := app.Group("/api/pekerjaan-mongo", middleware.AuthRequired())
+// SetupPekerjaanMongoRoutes memasang /pekerjaan-mongo di bawah auth (group yang
+// sudah memakai AuthRequired)
+func SetupPekerjaanMongoRoutes(auth fiber.Router, svc *service.PekerjaanMongoService) {
+	api := auth.Group("/pekerjaan-mongo")
 
 	// ========== READ (semua user login bisa) ==========
 	api.Get("/", func(c *fiber.Ctx) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		data, err := svc.GetAll(ctx)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "pekerjaan_fetch_failed")
 		}
 		return c.JSON(data)
 	})
 
 	api.Get("/:id", func(c *fiber.Ctx) error {
 		id := c.Params("id")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			return apperror.Invalid("invalid_pekerjaan_id")
+		}
+		ctx := c.UserContext()
 
 		data, err := svc.GetByID(ctx, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperror.NotFound("pekerjaan_not_found")
+		}
 		if err != nil {
-			return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "pekerjaan_fetch_failed")
 		}
 		return c.JSON(data)
 	})
 
-	// ========== ADMIN ONLY (READ + WRITE) ==========
-	admin := api.Group("", middleware.AdminOnly())
+	// ========== BUTUH pekerjaan:write (READ + WRITE) ==========
+	admin := api.Group("", middleware.Require(models.PermPekerjaanWrite))
 
-	// GET /api/pekerjaan-mongo/alumni/:alumni_id → Admin only
+	// GET /api/pekerjaan-mongo/alumni/:alumni_id → butuh pekerjaan:write
 	admin.Get("/alumni/:alumni_id", func(c *fiber.Ctx) error {
 		id, _ := strconv.Atoi(c.Params("alumni_id"))
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		data, err := svc.GetByAlumniID(ctx, id)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "pekerjaan_fetch_failed")
 		}
 		return c.JSON(data)
 	})
 
-	// POST → Tambah data (hanya admin)
+	// POST → Tambah data (butuh pekerjaan:write)
 	admin.Post("/", func(c *fiber.Ctx) error {
 		var input models.PekerjaanMongo
 		if err := c.BodyParser(&input); err != nil {
-			return c.Status(400).JSON(fiber.Map{"error": "JSON tidak valid"})
+			return apperror.InvalidPayload()
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		result, err := svc.Create(ctx, &input)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "pekerjaan_create_failed")
 		}
 		return c.Status(201).JSON(result)
 	})
 
-	// PUT → Update data (hanya admin)
+	// PUT → Update data (butuh pekerjaan:write)
 	admin.Put("/:id", func(c *fiber.Ctx) error {
 		id := c.Params("id")
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			return apperror.Invalid("invalid_pekerjaan_id")
+		}
 		var input models.PekerjaanMongo
 
 		if err := c.BodyParser(&input); err != nil {
-			return c.Status(400).JSON(fiber.Map{"error": "JSON tidak valid"})
+			return apperror.InvalidPayload()
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		result, err := svc.Update(ctx, id, &input)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperror.NotFound("pekerjaan_not_found")
+		}
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return apperror.Wrap(err, "pekerjaan_update_failed")
 		}
 		return c.JSON(result)
 	})
 
-	// DELETE → Hapus data (hanya admin)
+	// DELETE → Hapus data (butuh pekerjaan:write)
 	admin.Delete("/:id", func(c *fiber.Ctx) error {
 		id := c.Params("id")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			return apperror.Invalid("invalid_pekerjaan_id")
+		}
+		ctx := c.UserContext()
 
-		if err := svc.Delete(ctx, id); err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		err := svc.Delete(ctx, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperror.NotFound("pekerjaan_not_found")
+		}
+		if err != nil {
+			return apperror.Wrap(err, "pekerjaan_delete_failed")
 		}
-		return c.JSON(fiber.Map{"message": "Data berhasil dihapus"})
+		return c.JSON(fiber.Map{"message": helper.Message(c, "pekerjaan_deleted")})
 	})
 }
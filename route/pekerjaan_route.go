@@ -0,0 +1,31 @@
+package route
+
+import (
+	"go_clean/app/handlers"
+	"go_clean/app/models"
+	"go_clean/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupPekerjaanRoutes memasang /pekerjaan di bawah auth dan /pekerjaan-pag
+// di bawah api. Izin per pekerjaan (pemilik atau pemegang permission) dicek
+// di PekerjaanService.
+func SetupPekerjaanRoutes(api, auth fiber.Router, h *handlers.PekerjaanHandler) {
+	pkj := auth.Group("/pekerjaan")
+	pkj.Get("/trash", h.Trash)
+	pkj.Get("/", h.GetAll)
+	pkj.Get("/:id", h.GetByID)
+	pkj.Get("/alumni/:alumni_id", h.GetByAlumniID)
+	pkj.Put("/:id", h.WithPrincipal(h.Update))
+	pkj.Put("/restore/:id", h.WithPrincipal(h.Restore))
+	pkj.Delete("/:id", h.WithPrincipal(h.Delete))
+	pkj.Delete("/hard-delete/:id", h.WithPrincipal(h.HardDelete))
+	// per route supaya tidak ikut mengenai /api/pekerjaan-pag dan /api/pekerjaan-mongo
+	pkj.Post("/", middleware.Require(models.PermPekerjaanWrite), h.Create)
+
+	// =======================
+	// PAGINATION
+	// =======================
+	api.Get("/pekerjaan-pag", h.List)
+}
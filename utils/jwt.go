@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"errors"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,32 +11,152 @@ import (
 	"go_clean/config"
 )
 
+// JWT menandatangani dan memverifikasi token dengan key set dari KeysDir.
+// Dibuat sekali saat startup lalu diteruskan ke service dan middleware.
+type JWT struct {
+	cfg  config.JWTConfig
+	keys *keySet
+}
+
+// NewJWT memuat key set dari cfg.KeysDir
+func NewJWT(cfg config.JWTConfig) (*JWT, error) {
+	ks, err := loadKeySet(cfg.KeysDir, cfg.ActiveKID)
+	if err != nil {
+		return nil, err
+	}
+	return &JWT{cfg: cfg, keys: ks}, nil
+}
+
+// Config mengembalikan konfigurasi JWT (TTL, issuer, audience)
+func (j *JWT) Config() config.JWTConfig {
+	return j.cfg
+}
+
+// JWKS mengembalikan semua public key untuk verifikasi offline oleh service lain
+func (j *JWT) JWKS() JWKSet {
+	return j.keys.jwks()
+}
+
+// audience untuk tiap purpose token: access token memakai audience dari
+// config, purpose lain diberi suffix ("<audience>:mfa", dst.)
+func (j *JWT) audience(purpose string) string {
+	if purpose == models.TokenPurposeAccess {
+		return j.cfg.Audience
+	}
+	return j.cfg.Audience + ":" + purpose
+}
+
+// signClaims mengisi registered claims; sub selalu ID user (username bisa
+// diganti), aud mengikuti claims.Purpose
+func (j *JWT) signClaims(claims models.JWTClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.Issuer = j.cfg.Issuer
+	claims.Audience = jwt.ClaimStrings{j.audience(claims.Purpose)}
+	claims.Subject = strconv.Itoa(claims.UserID)
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+
+	key := j.keys.active
+	tok := jwt.NewWithClaims(key.method, claims)
+	tok.Header["kid"] = key.kid
+	return tok.SignedString(key.private)
+}
 
-func GenerateToken(u models.User) (string, error) {
-	jwtCfg := config.LoadJWT()
-	claims := models.JWTClaims{
+func (j *JWT) GenerateToken(u models.User, sessionID string, permissions []string) (string, error) {
+	return j.signClaims(models.JWTClaims{
+		UserID:        u.ID,
+		Username:      u.Username,
+		Role:          u.Role,
+		Permissions:   permissions,
+		SessionID:     sessionID,
+		EmailVerified: u.EmailVerified,
+		Locale:        u.Locale,
+	}, j.cfg.AccessTTL)
+}
+
+// GenerateImpersonationToken membuat access token atas nama u dengan claim act = admin
+func (j *JWT) GenerateImpersonationToken(u models.User, sessionID string, permissions []string, actor models.User, ttl time.Duration) (string, error) {
+	return j.signClaims(models.JWTClaims{
+		UserID:        u.ID,
+		Username:      u.Username,
+		Role:          u.Role,
+		Permissions:   permissions,
+		SessionID:     sessionID,
+		EmailVerified: u.EmailVerified,
+		Locale:        u.Locale,
+		Purpose:       models.TokenPurposeImpersonation,
+		Actor: &models.ActorClaim{
+			Subject:  strconv.Itoa(actor.ID),
+			UserID:   actor.ID,
+			Username: actor.Username,
+		},
+	}, ttl)
+}
+
+// GenerateChallengeToken membuat token singkat untuk langkah kedua login (2FA).
+// Token ini tidak punya sesi dan ditolak oleh middleware AuthRequired.
+func (j *JWT) GenerateChallengeToken(u models.User, ttl time.Duration) (string, error) {
+	return j.signClaims(models.JWTClaims{
 		UserID:   u.ID,
 		Username: u.Username,
 		Role:     u.Role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(jwtCfg.TTLHours) * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		Purpose:  models.TokenPurposeMFA,
+	}, ttl)
+}
+
+// GenerateEmailVerificationToken membuat token bertanda tangan untuk link
+// verifikasi email. Email ikut ditandatangani supaya link gugur saat email diganti.
+func (j *JWT) GenerateEmailVerificationToken(u models.User, ttl time.Duration) (string, error) {
+	return j.signClaims(models.JWTClaims{
+		UserID:   u.ID,
+		Username: u.Username,
+		Email:    u.Email,
+		Purpose:  models.TokenPurposeVerifyEmail,
+	}, ttl)
+}
+
+// GenerateRefreshToken membuat refresh token acak beserta hash yang disimpan di DB
+func GenerateRefreshToken() (raw string, hash string, err error) {
+	raw, err = RandomToken(32)
+	if err != nil {
+		return "", "", err
 	}
-	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return tok.SignedString(jwtCfg.Secret)
+	return raw, HashToken(raw), nil
 }
 
-func ValidateToken(tokenStr string) (*models.JWTClaims, error) {
-	jwtCfg := config.LoadJWT()
+// ValidateToken memverifikasi tanda tangan (key dipilih dari header kid),
+// masa berlaku, dan issuer, lalu memastikan purpose token termasuk purposes
+// dan audience-nya cocok dengan purpose tersebut
+func (j *JWT) ValidateToken(tokenStr string, purposes ...string) (*models.JWTClaims, error) {
+	if len(purposes) == 0 {
+		return nil, errors.New("purpose token yang diharapkan wajib diisi")
+	}
 	tok, err := jwt.ParseWithClaims(tokenStr, &models.JWTClaims{}, func(t *jwt.Token) (interface{}, error) {
-		return jwtCfg.Secret, nil
-	})
+		kid, _ := t.Header["kid"].(string)
+		key, ok := j.keys.keys[kid]
+		if !ok {
+			return nil, errors.New("kid tidak dikenal")
+		}
+		// cegah alg confusion: alg di header harus sesuai tipe key
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, errors.New("alg tidak cocok dengan key")
+		}
+		return key.public, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(j.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return nil, err
 	}
-	if claims, ok := tok.Claims.(*models.JWTClaims); ok && tok.Valid {
-		return claims, nil
+	claims, ok := tok.Claims.(*models.JWTClaims)
+	if !ok || !tok.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	if !slices.Contains(purposes, claims.Purpose) ||
+		!slices.Contains(claims.Audience, j.audience(claims.Purpose)) {
+		return nil, jwt.ErrTokenInvalidAudience
 	}
-	return nil, jwt.ErrTokenInvalidClaims
+	return claims, nil
 }
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"unicode"
+
+	"go_clean/config"
+)
+
+// PasswordProblem adalah satu aturan password policy yang dilanggar. Code
+// menjadi kunci pesan katalog i18n ("field.<Code>"), Args parameternya.
+type PasswordProblem struct {
+	Code string
+	Args []any
+}
+
+// CheckPasswordPolicy mengembalikan daftar aturan yang dilanggar (kosong = lolos)
+func CheckPasswordPolicy(pw string, p config.PasswordPolicy) []PasswordProblem {
+	var upper, lower, digit, symbol bool
+	for _, r := range pw {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	var problems []PasswordProblem
+	if len([]rune(pw)) < p.MinLength {
+		problems = append(problems, PasswordProblem{Code: "password_too_short", Args: []any{p.MinLength}})
+	}
+	if p.RequireUpper && !upper {
+		problems = append(problems, PasswordProblem{Code: "password_needs_upper"})
+	}
+	if p.RequireLower && !lower {
+		problems = append(problems, PasswordProblem{Code: "password_needs_lower"})
+	}
+	if p.RequireDigit && !digit {
+		problems = append(problems, PasswordProblem{Code: "password_needs_digit"})
+	}
+	if p.RequireSymbol && !symbol {
+		problems = append(problems, PasswordProblem{Code: "password_needs_symbol"})
+	}
+	return problems
+}